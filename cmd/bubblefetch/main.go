@@ -17,13 +17,14 @@ import (
 	"github.com/howieduhzit/bubblefetch/internal/config"
 	"github.com/howieduhzit/bubblefetch/internal/export"
 	"github.com/howieduhzit/bubblefetch/internal/plugins"
+	"github.com/howieduhzit/bubblefetch/internal/server"
+	"github.com/howieduhzit/bubblefetch/internal/snapshot"
 	"github.com/howieduhzit/bubblefetch/internal/solana"
 	"github.com/howieduhzit/bubblefetch/internal/ui"
 	"github.com/howieduhzit/bubblefetch/internal/ui/config_wizard"
 	"github.com/howieduhzit/bubblefetch/internal/ui/modules"
 	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
 	"github.com/howieduhzit/bubblefetch/internal/whois"
-	"github.com/mattn/go-runewidth"
 )
 
 var (
@@ -31,9 +32,9 @@ var (
 	configPathS      = flag.String("c", "", "Alias for --config")
 	themeName        = flag.String("theme", "", "Theme name to use")
 	themeNameS       = flag.String("t", "", "Alias for --theme")
-	remoteSys        = flag.String("remote", "", "Remote system IP/hostname to fetch info from (via SSH)")
+	remoteSys        = flag.String("remote", "", "Remote target to fetch info from (SSH host or docker://, podman://, k8s://, machinectl://, chroot:// URI)")
 	remoteSysS       = flag.String("r", "", "Alias for --remote")
-	exportFmt        = flag.String("export", "", "Export format: json, yaml, or text")
+	exportFmt        = flag.String("export", "", "Export format: json, yaml, toml, csv, env, markdown, text, or ansi")
 	exportFmtS       = flag.String("e", "", "Alias for --export")
 	pretty           = flag.Bool("pretty", true, "Pretty print JSON output (default: true)")
 	prettyS          = flag.Bool("p", true, "Alias for --pretty")
@@ -45,9 +46,12 @@ var (
 	versionFlagS     = flag.Bool("v", false, "Alias for --version")
 	configWizard     = flag.Bool("config-wizard", false, "Run interactive configuration wizard")
 	configWizardS    = flag.Bool("w", false, "Alias for --config-wizard")
-	imageExport      = flag.String("image-export", "", "Export as image: png, svg, or html")
+	imageExport      = flag.String("image-export", "", "Export as image: png, svg, html, or gif")
 	imageOutput      = flag.String("image-output", "", "Image output path (default: bubblefetch.{format})")
 	imageOutputS     = flag.String("o", "", "Alias for --image-output")
+	frames           = flag.Int("frames", 0, "Frames for animated gif/svg export (default: 10 for gif, 1 for svg)")
+	frameInterval    = flag.Duration("frame-interval", 0, "Time between animation frames (default: 1s)")
+	imageScale       = flag.Float64("image-scale", 0, "Pixel scale for PNG export, e.g. 2 for HiDPI (default: 1)")
 	whoisTarget      = flag.String("who", "", "Domain scan (WHOIS + DNS records)")
 	whoisTargetS     = flag.String("W", "", "Alias for --who")
 	whoisRaw         = flag.Bool("who-raw", false, "Include raw WHOIS output")
@@ -55,41 +59,77 @@ var (
 	solAddress       = flag.String("sol", "", "Fetch Solana token data by contract address")
 	solAddressS      = flag.String("s", "", "Alias for --sol")
 	remoteSafe       = flag.Bool("remote-safe", false, "Use read-only SSH commands (no shell pipelines)")
+	saveSnapshot     = flag.Bool("save-snapshot", false, "Save the collected info as a timestamped snapshot")
+	diffFrom         = flag.String("diff", "", "Compare two snapshots: --diff <a> [b] (b defaults to live)")
+	serveAddr        = flag.String("serve", "", "Serve the HTTP API and metrics on the given address (e.g. :9101)")
+	serveInterval    = flag.Duration("serve-interval", 5*time.Second, "Minimum time between collections in serve mode")
+	serveToken       = flag.String("serve-token", "", "Bearer token required in serve mode (or BUBBLEFETCH_SERVE_TOKEN)")
+	serveAllow       = flag.String("serve-allow", "", "Comma-separated client IPs/CIDRs allowed in serve mode")
+	colorMode        = flag.String("color", "", "Color output: auto, always, or never (default: auto)")
+	backgroundMode   = flag.String("background", "", "Terminal background for light/dark themes: auto, light, or dark (default: auto)")
+	logoPath         = flag.String("logo", "", "Image logo (PNG, JPEG, SVG) instead of ASCII art, or auto for the distro's built-in logo")
+	printSchema      = flag.Bool("print-schema", false, "Print the JSON Schema for --export json/yaml")
 	helpFlag         = flag.Bool("help", false, "Show help message")
 	helpFlagS        = flag.Bool("h", false, "Alias for --help")
 )
 
 const Version = "0.3.1"
-const whoisValueMaxWidth = 72
+
+// whoisDefaultWidth is the line width for --who output that is not going to
+// a terminal.
+const whoisDefaultWidth = 80
 
 func main() {
 	flag.Usage = func() {
 		fmt.Fprint(os.Stderr, `Usage: bubblefetch [OPTIONS]
+       bubblefetch themes list|show|validate|preview
 
 Options:
   -c, --config string         Path to config file (default: ~/.config/bubblefetch/config.yaml)
   -t, --theme string          Theme name to use (overrides config)
-  -r, --remote string         Remote system IP/hostname to fetch info from (via SSH)
-  -e, --export string         Export format: json, yaml, or text
+  --color string              Color output: auto, always, or never (default: auto; honors NO_COLOR)
+  --background string         Background for themes with light/dark colors: auto, light, or dark
+                              (default: auto; asks the terminal, then checks COLORFGBG)
+  --logo string               Image logo (PNG, JPEG, SVG) instead of ASCII art, or auto for
+                              the distro's built-in logo
+  -r, --remote string         Remote target: SSH host, or docker://name, podman://name,
+                              k8s://ns/pod[/container], machinectl://name, chroot:///path
+  -e, --export string         Export format: json, yaml, toml, csv, env, markdown, text, or ansi
   -p, --pretty                Pretty print JSON output (default: true)
   -b, --benchmark             Run benchmark mode (10 iterations)
   -f, --format string         Benchmark output format: text or json
   -w, --config-wizard         Run interactive configuration wizard
-  --image-export string       Export as image: png, svg, or html
+  --image-export string       Export as image: png, svg, html, or gif
   -o, --image-output string   Image output path (default: bubblefetch.{format})
+  --image-scale float         Pixel scale for PNG export, e.g. 2 for HiDPI (default: 1)
+  --frames int                Frames for animated gif/svg export (default: 10 for gif, 1 for svg)
+  --frame-interval duration   Time between animation frames (default: 1s)
   -W, --who string            Domain scan (WHOIS + DNS records)
   -R, --who-raw               Include raw WHOIS output
   -s, --sol string            Fetch Solana token data by contract address
   --remote-safe               Use read-only SSH commands (no shell pipelines)
+  --save-snapshot             Save the collected info as a timestamped snapshot
+  --diff a [b]                Compare snapshot a with snapshot b (default: live)
+  --serve string              Serve the HTTP API and Prometheus metrics on the given address (e.g. :9101)
+  --serve-interval duration   Minimum time between collections in serve mode (default: 5s)
+  --serve-token string        Bearer token required in serve mode (or BUBBLEFETCH_SERVE_TOKEN)
+  --serve-allow string        Comma-separated client IPs/CIDRs allowed in serve mode
+  --print-schema              Print the JSON Schema for --export json/yaml
   -v, --version               Print version information
   -h, --help                  Show help message
 
 Notes:
   - If --image-export is omitted, the format is inferred from --image-output extension.
   - Add "costs" to your modules list to display per-module timing.
+  - Snapshots may be referenced by path, file name, "latest", "previous" or "live".
 `)
 	}
+	if len(os.Args) > 1 && os.Args[1] == "themes" {
+		os.Exit(runThemes(os.Args[2:]))
+	}
+
 	flag.Parse()
+	diffTo := parseDiffArgs()
 	normalizeFlags()
 
 	if *helpFlag {
@@ -102,6 +142,25 @@ Notes:
 		os.Exit(0)
 	}
 
+	if *printSchema {
+		schema, err := export.JSONSchema()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating schema: %v\n", err)
+			os.Exit(1)
+		}
+		os.Stdout.Write(schema)
+		return
+	}
+
+	if err := ui.ConfigureColor(*colorMode); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := ui.ConfigureBackground(*backgroundMode); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Run config wizard if requested
 	if *configWizard {
 		wizard := config_wizard.NewModel()
@@ -134,6 +193,32 @@ Notes:
 	if *themeName != "" {
 		cfg.Theme = *themeName
 	}
+	if *colorMode != "" {
+		cfg.Color = *colorMode
+	}
+	if err := ui.ConfigureColor(cfg.Color); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := modules.ValidateCustom(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		os.Exit(1)
+	}
+	if *backgroundMode != "" {
+		cfg.Background = *backgroundMode
+	}
+	if *logoPath != "" {
+		cfg.Logo = *logoPath
+	}
+	if err := ui.ConfigureBackground(cfg.Background); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		os.Exit(1)
+	}
+	thm, err := theme.Load(cfg.Theme)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\nUsing the default theme.\n", err)
+		thm, _ = theme.Load("default")
+	}
 	if *remoteSafe {
 		cfg.SSH.SafeMode = true
 	}
@@ -155,12 +240,35 @@ Notes:
 	if pluginDir != "" {
 		timeout := time.Duration(cfg.ExternalModuleTimeoutMS) * time.Millisecond
 		pm := plugins.NewPluginManager(timeout)
+		width := cfg.Layout.Width
+		if width <= 0 {
+			width = ui.TerminalWidth()
+		}
+		pm.SetHost(plugins.Host{
+			Version: Version,
+			Colors:  thm.Colors,
+			Config:  cfg.PluginConfig,
+			Remote:  cfg.Remote,
+			Width:   width,
+		})
 		if err := pm.LoadPlugins(pluginDir); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: error loading plugins: %v\n", err)
 		}
+		closePlugins = pm.Close
+		defer closePlugins()
 		modules.InitPlugins(pm)
 	}
 
+	if *diffFrom != "" {
+		runDiff(cfg, *diffFrom, diffTo)
+		return
+	}
+
+	if *serveAddr != "" {
+		runServe(cfg, *serveAddr)
+		return
+	}
+
 	// Handle image export mode
 	if *imageExport != "" {
 		runImageExport(cfg)
@@ -176,6 +284,16 @@ Notes:
 	runFetch(cfg)
 }
 
+// closePlugins stops the RPC plugins once they are loaded.
+var closePlugins = func() {}
+
+// exit stops the RPC plugins, which deferred calls would not do, then exits
+// with code.
+func exit(code int) {
+	closePlugins()
+	os.Exit(code)
+}
+
 func normalizeFlags() {
 	if *configPathS != "" && *configPath == "" {
 		*configPath = *configPathS
@@ -233,22 +351,66 @@ func inferImageFormat(outputPath string) string {
 		return "svg"
 	case ".html", ".htm":
 		return "html"
+	case ".gif":
+		return "gif"
 	default:
 		return ""
 	}
 }
 
-func runExportMode(cfg *config.Config) {
-	// Create collector
-	var collector interface {
-		Collect() (*collectors.SystemInfo, error)
+// parseDiffArgs takes the optional second snapshot reference that follows
+// --diff and resumes flag parsing after it. Any further argument is an error.
+func parseDiffArgs() string {
+	if *diffFrom == "" || flag.NArg() == 0 {
+		return "live"
+	}
+	to := flag.Arg(0)
+	if err := flag.CommandLine.Parse(flag.Args()[1:]); err != nil {
+		os.Exit(2)
+	}
+	if flag.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: --diff compares two snapshots; unexpected argument %q\n", flag.Arg(0))
+		os.Exit(2)
+	}
+	return to
+}
+
+func newCollector(cfg *config.Config) collectors.Collector {
+	if cfg.Remote != "" {
+		return remote.New(cfg.Remote, cfg)
 	}
+	return local.New(cfg.EnablePublicIP)
+}
 
+// collectorName identifies the collector recorded in export documents.
+func collectorName(cfg *config.Config) string {
 	if cfg.Remote != "" {
-		collector = remote.New(cfg.Remote, cfg)
-	} else {
-		collector = local.New(cfg.EnablePublicIP)
+		return remote.TransportName(cfg.Remote)
 	}
+	return "local"
+}
+
+func newDocument(cfg *config.Config, info *collectors.SystemInfo) *export.Document {
+	return export.NewDocument(info, export.Source{
+		Version:   Version,
+		Collector: collectorName(cfg),
+	}, cfg.Modules)
+}
+
+func saveSnapshotIfRequested(cfg *config.Config, info *collectors.SystemInfo) {
+	if !*saveSnapshot || info == nil {
+		return
+	}
+	path, err := snapshot.Save(info, cfg.Remote)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving snapshot: %v\n", err)
+		exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Saved snapshot to %s\n", path)
+}
+
+func runExportMode(cfg *config.Config) {
+	collector := newCollector(cfg)
 
 	// Collect system info
 	var info *collectors.SystemInfo
@@ -273,7 +435,7 @@ func runExportMode(cfg *config.Config) {
 
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error collecting info: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 
@@ -309,7 +471,7 @@ func runExportMode(cfg *config.Config) {
 			encoded, err := json.MarshalIndent(out, "", "  ")
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding benchmark JSON: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			fmt.Println(string(encoded))
 			return
@@ -327,68 +489,157 @@ func runExportMode(cfg *config.Config) {
 	info, err = collector.Collect()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error collecting system info: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
+	saveSnapshotIfRequested(cfg, info)
 
 	// Export in requested format
 	var output string
 	switch *exportFmt {
 	case "json":
-		output, err = export.ToJSON(info, *pretty)
+		output, err = export.ToJSON(newDocument(cfg, info), *pretty)
 	case "yaml":
-		output, err = export.ToYAML(info)
+		output, err = export.ToYAML(newDocument(cfg, info))
+	case "toml":
+		output, err = export.ToTOML(newDocument(cfg, info))
+	case "csv":
+		output, err = export.ToCSV(newDocument(cfg, info))
+	case "env":
+		output, err = export.ToEnv(newDocument(cfg, info))
+	case "markdown", "md":
+		output = export.ToMarkdown(newDocument(cfg, info))
 	case "text":
-		output = export.ToText(info)
+		output = export.ToText(newDocument(cfg, info))
+	case "ansi":
+		// The themed render is the point of this format, so keep colors
+		// when redirected to a file unless they were turned off explicitly.
+		if cfg.Color != ui.ColorNever {
+			ui.ConfigureColor(ui.ColorAlways)
+		}
+		output = ui.RenderExport(cfg, info) + "\n"
 	default:
-		fmt.Fprintf(os.Stderr, "Unknown export format: %s (use json, yaml, or text)\n", *exportFmt)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "Unknown export format: %s (use json, yaml, toml, csv, env, markdown, text, or ansi)\n", *exportFmt)
+		exit(1)
 	}
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error exporting: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	fmt.Print(output)
 }
 
 func runFetch(cfg *config.Config) {
-	var collector collectors.Collector
-	if cfg.Remote != "" {
-		collector = remote.New(cfg.Remote, cfg)
-	} else {
-		collector = local.New(cfg.EnablePublicIP)
+	// Only the fetch view is printed straight to the terminal, so only it
+	// may draw the logo with a graphics protocol.
+	if err := ui.ConfigureGraphics(cfg.LogoProtocol); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		exit(1)
+	}
+	info, err := newCollector(cfg).Collect()
+	if err == nil {
+		saveSnapshotIfRequested(cfg, info)
 	}
-
-	info, err := collector.Collect()
 	output := ui.Render(cfg, info, err)
 	fmt.Println(output)
 }
 
-func runImageExport(cfg *config.Config) {
-	// Create collector
-	var collector interface {
-		Collect() (*collectors.SystemInfo, error)
+func runServe(cfg *config.Config, addr string) {
+	token := cfg.Serve.Token
+	if env := os.Getenv("BUBBLEFETCH_SERVE_TOKEN"); env != "" {
+		token = env
+	}
+	if *serveToken != "" {
+		token = *serveToken
+	}
+	allow := cfg.Serve.Allow
+	if *serveAllow != "" {
+		allow = strings.Split(*serveAllow, ",")
 	}
 
-	if cfg.Remote != "" {
-		collector = remote.New(cfg.Remote, cfg)
-	} else {
-		collector = local.New(cfg.EnablePublicIP)
+	srv, err := server.New(newCollector(cfg), server.Options{
+		MinInterval: *serveInterval,
+		Version:     Version,
+		Collector:   collectorName(cfg),
+		Config:      cfg,
+		Token:       token,
+		Allow:       allow,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error configuring server: %v\n", err)
+		exit(1)
 	}
 
-	// Collect system info
-	info, err := collector.Collect()
+	fmt.Fprintf(os.Stderr, "Serving on %s (/v1/info, /v1/render, /metrics, /healthz)\n", addr)
+	if err := srv.ListenAndServe(addr); err != nil {
+		fmt.Fprintf(os.Stderr, "Error serving: %v\n", err)
+		exit(1)
+	}
+}
+
+func runDiff(cfg *config.Config, fromRef, toRef string) {
+	from, err := loadSnapshotRef(cfg, fromRef)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error collecting system info: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "Error loading snapshot %s: %v\n", fromRef, err)
+		exit(1)
+	}
+	to, err := loadSnapshotRef(cfg, toRef)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading snapshot %s: %v\n", toRef, err)
+		exit(1)
 	}
 
-	// Create image exporter
-	exporter, err := export.NewImageExporter(info, cfg)
+	result := snapshot.Compare(from, to)
+
+	switch *exportFmt {
+	case "":
+		fmt.Println(ui.RenderDiff(cfg, result))
+	case "json":
+		var encoded []byte
+		if *pretty {
+			encoded, err = json.MarshalIndent(result, "", "  ")
+		} else {
+			encoded, err = json.Marshal(result)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding diff JSON: %v\n", err)
+			exit(1)
+		}
+		fmt.Println(string(encoded))
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown diff export format: %s (use json)\n", *exportFmt)
+		exit(1)
+	}
+}
+
+// loadSnapshotRef loads a stored snapshot, or collects one when ref is "live".
+func loadSnapshotRef(cfg *config.Config, ref string) (*snapshot.Snapshot, error) {
+	if ref != "live" {
+		return snapshot.Load(ref)
+	}
+	info, err := newCollector(cfg).Collect()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating image exporter: %v\n", err)
-		os.Exit(1)
+		return nil, err
+	}
+	saveSnapshotIfRequested(cfg, info)
+	return &snapshot.Snapshot{
+		Timestamp: time.Now().UTC(),
+		Host:      info.Hostname,
+		Source:    cfg.Remote,
+		Info:      info,
+	}, nil
+}
+
+func runImageExport(cfg *config.Config) {
+	if *imageScale > 0 {
+		cfg.Image.Scale = *imageScale
+	}
+	if *frames > 0 {
+		cfg.Image.Frames = *frames
+	}
+	if *frameInterval > 0 {
+		cfg.Image.FrameIntervalMS = int(frameInterval.Milliseconds())
 	}
 
 	// Determine output path
@@ -397,6 +648,28 @@ func runImageExport(cfg *config.Config) {
 		outputPath = "bubblefetch." + *imageExport
 	}
 
+	animated := *imageExport == "gif" || (*imageExport == "svg" && cfg.Image.Frames > 1)
+	if animated {
+		runAnimatedExport(cfg, outputPath)
+		return
+	}
+
+	// Collect system info
+	info, err := newCollector(cfg).Collect()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error collecting system info: %v\n", err)
+		exit(1)
+	}
+	saveSnapshotIfRequested(cfg, info)
+
+	// Create image exporter
+	exporter, err := export.NewImageExporter(info, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating image exporter: %v\n", err)
+		exit(1)
+	}
+	exporter.SetSource(export.Source{Version: Version, Collector: collectorName(cfg)})
+
 	// Export based on format
 	switch *imageExport {
 	case "png":
@@ -406,13 +679,61 @@ func runImageExport(cfg *config.Config) {
 	case "html":
 		err = exporter.ToHTML(outputPath)
 	default:
-		fmt.Fprintf(os.Stderr, "Unknown image format: %s (use png, svg, or html)\n", *imageExport)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "Unknown image format: %s (use png, svg, html, or gif)\n", *imageExport)
+		exit(1)
 	}
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error exporting image: %v\n", err)
-		os.Exit(1)
+		exit(1)
+	}
+
+	fmt.Printf("Successfully exported to %s\n", outputPath)
+}
+
+// runAnimatedExport samples system info repeatedly and writes one frame per
+// sample as a GIF or animated SVG.
+func runAnimatedExport(cfg *config.Config, outputPath string) {
+	count := cfg.Image.Frames
+	if count <= 0 {
+		count = 10
+	}
+	interval := time.Duration(cfg.Image.FrameIntervalMS) * time.Millisecond
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	collector := newCollector(cfg)
+	samples := make([]*collectors.SystemInfo, 0, count)
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		fmt.Fprintf(os.Stderr, "\rSampling frame %d/%d", i+1, count)
+		info, err := collector.Collect()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\nError collecting system info: %v\n", err)
+			exit(1)
+		}
+		samples = append(samples, info)
+	}
+	fmt.Fprintln(os.Stderr)
+	saveSnapshotIfRequested(cfg, samples[len(samples)-1])
+
+	animation, err := export.NewAnimation(samples, cfg, interval)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating animation: %v\n", err)
+		exit(1)
+	}
+
+	if *imageExport == "gif" {
+		err = animation.ToGIF(outputPath)
+	} else {
+		err = animation.ToSVG(outputPath)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error exporting animation: %v\n", err)
+		exit(1)
 	}
 
 	fmt.Printf("Successfully exported to %s\n", outputPath)
@@ -438,7 +759,14 @@ func runWhois(target string, includeRaw bool) {
 		os.Exit(1)
 	}
 
-	fmt.Println(formatWhois(result, thm))
+	width := cfg.Layout.Width
+	if width == 0 {
+		width = ui.TerminalWidth()
+	}
+	if width == 0 {
+		width = whoisDefaultWidth
+	}
+	fmt.Println(formatWhois(result, thm, width))
 }
 
 func runSolana(address string) {
@@ -500,7 +828,9 @@ func runSolana(address string) {
 	fmt.Println(output)
 }
 
-func formatWhois(result whois.Result, thm *theme.Theme) string {
+// formatWhois renders a domain scan in width columns, truncating long values
+// and wrapping the raw WHOIS text.
+func formatWhois(result whois.Result, thm *theme.Theme, width int) string {
 	styles := thm.GetStyles()
 	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(thm.Colors.Accent))
 	subhead := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(thm.Colors.Primary))
@@ -514,7 +844,7 @@ func formatWhois(result whois.Result, thm *theme.Theme) string {
 	b.WriteString("\n")
 	b.WriteString(styles.Label.Render(icon("󰮱 ") + "Target"))
 	b.WriteString(separator)
-	b.WriteString(styles.Value.Render(truncateWhoisValue(result.Target)))
+	b.WriteString(styles.Value.Render(result.Target))
 	b.WriteString("\n\n")
 
 	b.WriteString(subhead.Render(icon("󰌪 ") + "WHOIS"))
@@ -527,7 +857,7 @@ func formatWhois(result whois.Result, thm *theme.Theme) string {
 			b.WriteString("  ")
 			b.WriteString(styles.Label.Render(field.Label))
 			b.WriteString(separator)
-			b.WriteString(styles.Value.Render(truncateWhoisValue(field.Value)))
+			b.WriteString(styles.Value.Render(field.Value))
 			b.WriteString("\n")
 		}
 	}
@@ -549,20 +879,19 @@ func formatWhois(result whois.Result, thm *theme.Theme) string {
 		b.WriteString(":\n")
 		for _, record := range result.DNS.TXT {
 			b.WriteString("    - ")
-			b.WriteString(styles.Value.Render(truncateWhoisValue(record)))
+			b.WriteString(styles.Value.Render(record))
 			b.WriteString("\n")
 		}
 	}
 
+	width -= styles.Border.GetHorizontalFrameSize()
+	body := ui.FitWidth(strings.TrimSuffix(b.String(), "\n"), width, modules.OverflowTruncate) + "\n"
 	if result.Whois.Raw != "" {
-		b.WriteString("\n")
-		b.WriteString(subhead.Render(icon("󰡯 ") + "RAW WHOIS"))
-		b.WriteString("\n")
-		b.WriteString(result.Whois.Raw)
-		b.WriteString("\n")
+		body += "\n" + subhead.Render(icon("󰡯 ")+"RAW WHOIS") + "\n"
+		body += ui.FitWidth(strings.TrimSuffix(result.Whois.Raw, "\n"), width, modules.OverflowWrap) + "\n"
 	}
 
-	return styles.Border.Render(b.String())
+	return styles.Border.Render(body)
 }
 
 func writeLine(b *strings.Builder, styles theme.Styles, separator, label, value string) {
@@ -572,7 +901,7 @@ func writeLine(b *strings.Builder, styles theme.Styles, separator, label, value
 	b.WriteString("  ")
 	b.WriteString(styles.Label.Render(label))
 	b.WriteString(separator)
-	b.WriteString(styles.Value.Render(truncateWhoisValue(value)))
+	b.WriteString(styles.Value.Render(value))
 	b.WriteString("\n")
 }
 
@@ -582,25 +911,3 @@ func writeList(b *strings.Builder, styles theme.Styles, separator, label string,
 	}
 	writeLine(b, styles, separator, label, strings.Join(values, ", "))
 }
-
-func truncateWhoisValue(value string) string {
-	if runewidth.StringWidth(value) <= whoisValueMaxWidth {
-		return value
-	}
-	if whoisValueMaxWidth <= 3 {
-		return strings.Repeat(".", whoisValueMaxWidth)
-	}
-
-	var b strings.Builder
-	width := 0
-	for _, r := range value {
-		w := runewidth.RuneWidth(r)
-		if width+w > whoisValueMaxWidth-3 {
-			break
-		}
-		b.WriteRune(r)
-		width += w
-	}
-	b.WriteString("...")
-	return b.String()
-}
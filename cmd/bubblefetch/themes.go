@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"github.com/howieduhzit/bubblefetch/internal/ui"
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+)
+
+const themesUsage = `Usage: bubblefetch themes <command> [args]
+
+Commands:
+  list                     List available themes
+  show <name>              Print a theme with inheritance applied, as JSON
+  validate [name|file...]  Check theme files (default: all themes)
+  preview [name...]        Render sample output for each theme side by side
+`
+
+// previewModules are the modules shown by "themes preview".
+var previewModules = []string{"os", "kernel", "uptime", "cpu", "memory", "shell", "wm"}
+
+// runThemes runs the themes subcommand and returns the exit code.
+func runThemes(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, themesUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "list":
+		return themesList()
+	case "show":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, "Usage: bubblefetch themes show <name>")
+			return 2
+		}
+		return themesShow(args[1])
+	case "validate":
+		return themesValidate(args[1:])
+	case "preview":
+		return themesPreview(args[1:])
+	case "-h", "--help", "help":
+		fmt.Print(themesUsage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown themes command: %s\n\n%s", args[0], themesUsage)
+		return 2
+	}
+}
+
+func themesList() int {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tEXTENDS\tPATH")
+	for _, name := range theme.List() {
+		path, _ := theme.Path(name)
+		if path == "" {
+			path = "(built-in)"
+		}
+		extends := "-"
+		thm, err := theme.Resolve(name)
+		if err != nil {
+			path += " (invalid)"
+		} else if thm.Extends != "" {
+			extends = thm.Extends
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, extends, path)
+	}
+	w.Flush()
+	return 0
+}
+
+func themesShow(name string) int {
+	thm, err := theme.Resolve(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	// The output is the fully resolved theme, so it no longer needs a parent.
+	thm.Extends = ""
+
+	data, err := json.MarshalIndent(thm, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Println(string(data))
+	return 0
+}
+
+func themesValidate(targets []string) int {
+	if len(targets) == 0 {
+		for _, name := range theme.List() {
+			if path, _ := theme.Path(name); path != "" {
+				targets = append(targets, path)
+			}
+		}
+	}
+
+	failed := 0
+	for _, target := range targets {
+		path := target
+		if theme.IsImport(target) {
+			if _, err := theme.Load(target); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed++
+				continue
+			}
+			fmt.Printf("ok  %s\n", target)
+			continue
+		}
+		if !isThemeFile(target) {
+			var err error
+			if path, err = theme.Path(target); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				failed++
+				continue
+			}
+			if path == "" {
+				if _, err := theme.Resolve(target); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					failed++
+					continue
+				}
+				fmt.Printf("ok  %s (built-in)\n", target)
+				continue
+			}
+		}
+
+		if _, err := theme.LoadFile(path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed++
+			continue
+		}
+		fmt.Printf("ok  %s\n", path)
+	}
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+func themesPreview(names []string) int {
+	if len(names) == 0 {
+		names = theme.List()
+	}
+
+	cfg := config.NewDefault()
+	cfg.Modules = config.Modules(previewModules...)
+
+	var blocks []string
+	status := 0
+	for _, name := range names {
+		thm, err := theme.Load(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			status = 1
+			continue
+		}
+		// ASCII art would make each sample too wide to compare side by side.
+		thm.Layout.ShowASCII = false
+
+		info := sampleInfo()
+		title := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(thm.Colors.Primary)).
+			Bold(true).
+			Render(name)
+		blocks = append(blocks, lipgloss.JoinVertical(lipgloss.Left, title, ui.RenderTheme(cfg, thm, &info)))
+	}
+
+	for _, row := range packRows(blocks, terminalWidth(), 2) {
+		fmt.Println(row)
+		fmt.Println()
+	}
+	return status
+}
+
+// packRows joins blocks horizontally, starting a new row whenever the next
+// block would not fit in width.
+func packRows(blocks []string, width, gap int) []string {
+	var rows []string
+	var row []string
+	rowWidth := 0
+	spacer := strings.Repeat(" ", gap)
+
+	for _, block := range blocks {
+		blockWidth := lipgloss.Width(block)
+		if len(row) > 0 && rowWidth+gap+blockWidth > width {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		if len(row) > 0 {
+			row = append(row, spacer)
+			rowWidth += gap
+		}
+		row = append(row, block)
+		rowWidth += blockWidth
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	return rows
+}
+
+func terminalWidth() int {
+	if width := ui.TerminalWidth(); width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
+
+// isThemeFile reports whether a validate target is a path rather than a
+// theme name.
+func isThemeFile(target string) bool {
+	return filepath.Ext(target) == ".json" || strings.ContainsRune(target, filepath.Separator)
+}
+
+// sampleInfo is fixed system info used to preview themes.
+func sampleInfo() collectors.SystemInfo {
+	return collectors.SystemInfo{
+		OS:       "Arch Linux",
+		Kernel:   "6.9.3-arch1-1",
+		Hostname: "bubble",
+		Uptime:   "3h 12m",
+		CPU:      "AMD Ryzen 7 7840U (16)",
+		Memory:   collectors.MemoryInfo{Used: 6 << 30, Total: 32 << 30},
+		Shell:    "zsh 5.9",
+		WM:       "Hyprland",
+	}
+}
@@ -0,0 +1,180 @@
+package remote
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+var darwinPlatform = remotePlatform{
+	name: "darwin",
+	commands: []remoteCommand{
+		{"os", "sw_vers 2>/dev/null"},
+		{"kernel", "uname -r 2>/dev/null"},
+		{"hostname", "scutil --get LocalHostName 2>/dev/null || hostname 2>/dev/null"},
+		{"uptime", "sysctl -n kern.boottime 2>/dev/null; date +%s"},
+		{"cpu", "sysctl -n machdep.cpu.brand_string 2>/dev/null"},
+		{"memory", "sysctl -n hw.memsize 2>/dev/null; vm_stat 2>/dev/null"},
+		{"disk", "df -k /System/Volumes/Data 2>/dev/null || df -k / 2>/dev/null"},
+		{"shell", "echo $SHELL"},
+		{"term", "echo $TERM"},
+		{"gpu", "system_profiler SPDisplaysDataType 2>/dev/null"},
+		{"network", "ifconfig 2>/dev/null"},
+		{"battery", "pmset -g batt 2>/dev/null"},
+	},
+	safeCommands: []safeCommand{
+		{"os", []string{"sw_vers"}},
+		{"kernel", []string{"uname -r"}},
+		{"hostname", []string{"hostname"}},
+		{"uptime", []string{"sysctl -n kern.boottime"}},
+		{"cpu", []string{"sysctl -n machdep.cpu.brand_string"}},
+		{"memory", []string{"sysctl -n hw.memsize"}},
+	},
+	parse: parseDarwin,
+}
+
+func parseDarwin(info *collectors.SystemInfo, results map[string]string) {
+	parseSwVers(info, results["os"])
+	info.Kernel = results["kernel"]
+	info.Hostname = results["hostname"]
+	parseBootTime(info, results["uptime"])
+	info.CPU = results["cpu"]
+	parseDarwinMemory(info, results["memory"])
+	parseDFKilobytes(info, results["disk"])
+	info.Shell = results["shell"]
+	info.Terminal = results["term"]
+	info.DE = "Aqua"
+	info.WM = "Quartz Compositor"
+	parseSystemProfilerGPU(info, results["gpu"])
+	parseIfconfig(info, results["network"])
+	parsePmset(info, results["battery"])
+}
+
+// parseSwVers builds "macOS 14.2.1 (23C71)" from `sw_vers` output.
+func parseSwVers(info *collectors.SystemInfo, output string) {
+	values := parseSysctlPairs(output)
+	name := values["ProductName"]
+	if name == "" {
+		info.OS = output
+		return
+	}
+	if name == "Mac OS X" {
+		name = "macOS"
+	}
+	parts := []string{name}
+	if version := values["ProductVersion"]; version != "" {
+		parts = append(parts, version)
+	}
+	if build := values["BuildVersion"]; build != "" {
+		parts = append(parts, "("+build+")")
+	}
+	info.OS = strings.Join(parts, " ")
+}
+
+var vmStatPageSize = regexp.MustCompile(`page size of (\d+) bytes`)
+
+// parseDarwinMemory parses `sysctl -n hw.memsize` followed by `vm_stat`.
+// Used memory follows Activity Monitor: active + wired + compressed pages.
+func parseDarwinMemory(info *collectors.SystemInfo, output string) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) == 0 {
+		return
+	}
+
+	total, err := strconv.ParseUint(strings.TrimSpace(lines[0]), 10, 64)
+	if err != nil || total == 0 {
+		return
+	}
+	info.Memory = collectors.MemoryInfo{Total: total}
+
+	pageSize := uint64(4096)
+	if match := vmStatPageSize.FindStringSubmatch(output); match != nil {
+		if parsed, err := strconv.ParseUint(match[1], 10, 64); err == nil {
+			pageSize = parsed
+		}
+	}
+
+	var pages uint64
+	for _, line := range lines[1:] {
+		idx := strings.Index(line, ":")
+		if idx == -1 {
+			continue
+		}
+		key := strings.TrimSpace(line[:idx])
+		switch key {
+		case "Pages active", "Pages wired down", "Pages occupied by compressor":
+			value := strings.TrimSuffix(strings.TrimSpace(line[idx+1:]), ".")
+			if parsed, err := strconv.ParseUint(value, 10, 64); err == nil {
+				pages += parsed
+			}
+		}
+	}
+
+	used := pages * pageSize
+	if used > total {
+		used = total
+	}
+	info.Memory.Used = used
+}
+
+// parseSystemProfilerGPU reads "Chipset Model:" entries from
+// `system_profiler SPDisplaysDataType`.
+func parseSystemProfilerGPU(info *collectors.SystemInfo, output string) {
+	for _, line := range strings.Split(output, "\n") {
+		clean := strings.TrimSpace(line)
+		if !strings.HasPrefix(clean, "Chipset Model:") {
+			continue
+		}
+		gpu := strings.TrimSpace(strings.TrimPrefix(clean, "Chipset Model:"))
+		if gpu != "" {
+			info.GPU = append(info.GPU, gpu)
+		}
+	}
+}
+
+// parsePmset reads the internal battery line from `pmset -g batt`, e.g.
+// " -InternalBattery-0 (id=4653155)	85%; discharging; 4:20 remaining present: true".
+func parsePmset(info *collectors.SystemInfo, output string) {
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.Contains(line, "InternalBattery") {
+			continue
+		}
+		idx := strings.Index(line, "\t")
+		if idx == -1 {
+			idx = strings.Index(line, ")")
+		}
+		if idx == -1 {
+			return
+		}
+
+		parts := strings.Split(line[idx+1:], ";")
+		percentage, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(parts[0]), "%"), 64)
+		if err != nil {
+			return
+		}
+
+		battery := collectors.BatteryInfo{
+			Present:    true,
+			Percentage: percentage,
+		}
+		if len(parts) > 1 {
+			status := strings.TrimSpace(parts[1])
+			battery.IsCharging = status == "charging" || status == "charged" || status == "finishing charge"
+		}
+		if len(parts) > 2 {
+			fields := strings.Fields(parts[2])
+			if len(fields) > 0 && strings.Contains(fields[0], ":") {
+				hm := strings.SplitN(fields[0], ":", 2)
+				hours, errH := strconv.Atoi(hm[0])
+				minutes, errM := strconv.Atoi(hm[1])
+				if errH == nil && errM == nil && (hours > 0 || minutes > 0) {
+					battery.TimeRemain = strconv.Itoa(hours) + "h " + strconv.Itoa(minutes) + "m"
+				}
+			}
+		}
+		info.Battery = battery
+		return
+	}
+}
@@ -0,0 +1,150 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+func TestParseSwVers(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "sonoma",
+			output: "ProductName:\t\tmacOS\nProductVersion:\t\t14.2.1\nBuildVersion:\t\t23C71\n",
+			want:   "macOS 14.2.1 (23C71)",
+		},
+		{
+			name:   "mac os x",
+			output: "ProductName:\tMac OS X\nProductVersion:\t10.15.7\nBuildVersion:\t19H2026\n",
+			want:   "macOS 10.15.7 (19H2026)",
+		},
+		{
+			name:   "no build",
+			output: "ProductName:\tmacOS\nProductVersion:\t13.0\n",
+			want:   "macOS 13.0",
+		},
+		{
+			name:   "unrecognized",
+			output: "Darwin",
+			want:   "Darwin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info collectors.SystemInfo
+			parseSwVers(&info, tt.output)
+			if info.OS != tt.want {
+				t.Errorf("OS = %q, want %q", info.OS, tt.want)
+			}
+		})
+	}
+}
+
+const vmStatOutput = `17179869184
+Mach Virtual Memory Statistics: (page size of 16384 bytes)
+Pages free:                               12345.
+Pages active:                            300000.
+Pages inactive:                          290000.
+Pages speculative:                         5000.
+Pages throttled:                              0.
+Pages wired down:                        150000.
+Pages purgeable:                          10000.
+"Translation faults":                 123456789.
+Pages copy-on-write:                    1234567.
+Pages zero filled:                     98765432.
+Pages reactivated:                       123456.
+Pages purged:                             65432.
+File-backed pages:                       200000.
+Anonymous pages:                         395000.
+Pages stored in compressor:              250000.
+Pages occupied by compressor:             80000.
+`
+
+func TestParseDarwinMemory(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   collectors.MemoryInfo
+	}{
+		{
+			name:   "vm_stat",
+			output: vmStatOutput,
+			// (active + wired + compressor) * page size
+			want: collectors.MemoryInfo{Total: 17179869184, Used: (300000 + 150000 + 80000) * 16384},
+		},
+		{
+			name:   "default page size",
+			output: "8589934592\nPages active: 1000.\nPages wired down: 24.\n",
+			want:   collectors.MemoryInfo{Total: 8589934592, Used: 1024 * 4096},
+		},
+		{
+			name:   "used capped at total",
+			output: "4096\nMach Virtual Memory Statistics: (page size of 4096 bytes)\nPages active: 10.\n",
+			want:   collectors.MemoryInfo{Total: 4096, Used: 4096},
+		},
+		{
+			name:   "memsize only",
+			output: "17179869184\n",
+			want:   collectors.MemoryInfo{Total: 17179869184},
+		},
+		{
+			name:   "no output",
+			output: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info collectors.SystemInfo
+			parseDarwinMemory(&info, tt.output)
+			if info.Memory != tt.want {
+				t.Errorf("Memory = %+v, want %+v", info.Memory, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePmset(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   collectors.BatteryInfo
+	}{
+		{
+			name:   "discharging",
+			output: "Now drawing from 'Battery Power'\n -InternalBattery-0 (id=4653155)\t85%; discharging; 4:20 remaining present: true\n",
+			want:   collectors.BatteryInfo{Present: true, Percentage: 85, TimeRemain: "4h 20m"},
+		},
+		{
+			name:   "charging",
+			output: "Now drawing from 'AC Power'\n -InternalBattery-0 (id=4653155)\t42%; charging; 1:05 remaining present: true\n",
+			want:   collectors.BatteryInfo{Present: true, Percentage: 42, IsCharging: true, TimeRemain: "1h 5m"},
+		},
+		{
+			name:   "charged",
+			output: "Now drawing from 'AC Power'\n -InternalBattery-0 (id=4653155)\t100%; charged; 0:00 remaining present: true\n",
+			want:   collectors.BatteryInfo{Present: true, Percentage: 100, IsCharging: true},
+		},
+		{
+			name:   "no estimate",
+			output: " -InternalBattery-0 (id=4653155)\t57%; discharging; (no estimate) present: true\n",
+			want:   collectors.BatteryInfo{Present: true, Percentage: 57},
+		},
+		{
+			name:   "desktop",
+			output: "Now drawing from 'AC Power'\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info collectors.SystemInfo
+			parsePmset(&info, tt.output)
+			if info.Battery != tt.want {
+				t.Errorf("Battery = %+v, want %+v", info.Battery, tt.want)
+			}
+		})
+	}
+}
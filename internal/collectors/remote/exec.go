@@ -0,0 +1,82 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const execTimeout = 10 * time.Second
+
+// ExecTransport runs commands through a local CLI such as `docker exec`,
+// `kubectl exec` or `chroot`, appending `/bin/sh -c <cmd>` to a fixed prefix.
+type ExecTransport struct {
+	name   string
+	prefix []string
+}
+
+// NewExec creates an exec-based transport. name is used in error messages and
+// prefix is the argv placed before the shell invocation.
+func NewExec(name string, prefix []string) *ExecTransport {
+	return &ExecTransport{
+		name:   name,
+		prefix: prefix,
+	}
+}
+
+// Connect checks that the transport's CLI is available locally and that the
+// target can run commands, so a missing container or pod is reported as an
+// error instead of being collected.
+func (t *ExecTransport) Connect() error {
+	if len(t.prefix) == 0 {
+		return fmt.Errorf("%s transport has no command", t.name)
+	}
+	if _, err := exec.LookPath(t.prefix[0]); err != nil {
+		return fmt.Errorf("%s transport unavailable: %v", t.name, err)
+	}
+	if _, err := t.run("uname -s"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Run executes cmd in the target's shell with a pinned PATH and locale.
+func (t *ExecTransport) Run(cmd string) (string, error) {
+	return t.run(remoteShellPrefix + cmd)
+}
+
+// RunRaw executes cmd in the target's shell as-is.
+func (t *ExecTransport) RunRaw(cmd string) (string, error) {
+	return t.run(cmd)
+}
+
+// run executes cmd and returns its stdout. When the command fails, the error
+// carries stderr, which never becomes part of the output.
+func (t *ExecTransport) run(cmd string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), execTimeout)
+	defer cancel()
+
+	args := append(append([]string{}, t.prefix[1:]...), "/bin/sh", "-c", cmd)
+	var stderr bytes.Buffer
+	command := exec.CommandContext(ctx, t.prefix[0], args...)
+	command.Stderr = &stderr
+	output, err := command.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("%s: timeout after %s", t.name, execTimeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return string(output), fmt.Errorf("%s: %s", t.name, msg)
+		}
+		return string(output), fmt.Errorf("%s: %w", t.name, err)
+	}
+	return string(output), nil
+}
+
+// Close is a no-op; each command runs in its own process.
+func (t *ExecTransport) Close() error {
+	return nil
+}
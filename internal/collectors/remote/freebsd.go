@@ -0,0 +1,174 @@
+package remote
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+var freebsdPlatform = remotePlatform{
+	name: "freebsd",
+	commands: []remoteCommand{
+		{"os", "echo \"$(uname -s) $(freebsd-version -u 2>/dev/null || uname -r)\""},
+		{"kernel", "uname -r 2>/dev/null"},
+		{"hostname", "hostname 2>/dev/null"},
+		{"uptime", "sysctl -n kern.boottime 2>/dev/null; date +%s"},
+		{"cpu", "sysctl -n hw.model 2>/dev/null"},
+		{"memory", "sysctl hw.physmem hw.pagesize vm.stats.vm.v_free_count vm.stats.vm.v_inactive_count vm.stats.vm.v_cache_count 2>/dev/null"},
+		{"disk", "out=$(df -k / 2>/dev/null | awk 'NR==2'); if [ -n \"$out\" ]; then echo \"$out\"; else geom disk list 2>/dev/null; fi"},
+		{"shell", "echo $SHELL"},
+		{"term", "echo $TERM"},
+		{"de", "echo $XDG_CURRENT_DESKTOP"},
+		{"wm", "echo $XDG_SESSION_TYPE"},
+		{"gpu", "pciconf -lv 2>/dev/null"},
+		{"network", "ifconfig 2>/dev/null"},
+		{"battery", "sysctl hw.acpi.battery.life hw.acpi.battery.state hw.acpi.battery.time 2>/dev/null"},
+	},
+	safeCommands: []safeCommand{
+		{"os", []string{"uname -sr"}},
+		{"kernel", []string{"uname -r"}},
+		{"hostname", []string{"hostname"}},
+		{"uptime", []string{"sysctl -n kern.boottime"}},
+		{"cpu", []string{"sysctl -n hw.model"}},
+		{"memory", []string{"sysctl hw.physmem hw.pagesize vm.stats.vm.v_free_count vm.stats.vm.v_inactive_count"}},
+	},
+	parse: parseFreeBSD,
+}
+
+func parseFreeBSD(info *collectors.SystemInfo, results map[string]string) {
+	info.OS = strings.TrimSpace(results["os"])
+	info.Kernel = results["kernel"]
+	info.Hostname = results["hostname"]
+	parseBootTime(info, results["uptime"])
+	info.CPU = results["cpu"]
+	parseFreeBSDMemory(info, results["memory"])
+	if strings.Contains(results["disk"], "Mediasize:") {
+		parseGeomDisk(info, results["disk"])
+	} else {
+		parseDFKilobytes(info, results["disk"])
+	}
+	info.Shell = results["shell"]
+	info.Terminal = results["term"]
+	info.DE = results["de"]
+	info.WM = results["wm"]
+	parsePciconfGPU(info, results["gpu"])
+	parseIfconfig(info, results["network"])
+	parseACPIBattery(info, results["battery"])
+}
+
+// parseFreeBSDMemory treats free, inactive and cache pages as available,
+// mirroring how top(1) reports usable memory.
+func parseFreeBSDMemory(info *collectors.SystemInfo, output string) {
+	values := parseSysctlPairs(output)
+	total, err := strconv.ParseUint(values["hw.physmem"], 10, 64)
+	if err != nil || total == 0 {
+		return
+	}
+
+	pageSize, err := strconv.ParseUint(values["hw.pagesize"], 10, 64)
+	if err != nil || pageSize == 0 {
+		pageSize = 4096
+	}
+
+	var freePages uint64
+	for _, key := range []string{
+		"vm.stats.vm.v_free_count",
+		"vm.stats.vm.v_inactive_count",
+		"vm.stats.vm.v_cache_count",
+	} {
+		if parsed, err := strconv.ParseUint(values[key], 10, 64); err == nil {
+			freePages += parsed
+		}
+	}
+
+	available := freePages * pageSize
+	if available > total {
+		available = total
+	}
+	info.Memory = collectors.MemoryInfo{
+		Total: total,
+		Used:  total - available,
+	}
+}
+
+// parseGeomDisk uses the first disk's Mediasize from `geom disk list` when df
+// is unavailable. Only the total is known in that case.
+func parseGeomDisk(info *collectors.SystemInfo, output string) {
+	for _, line := range strings.Split(output, "\n") {
+		clean := strings.TrimSpace(line)
+		if !strings.HasPrefix(clean, "Mediasize:") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(clean, "Mediasize:"))
+		if len(fields) == 0 {
+			return
+		}
+		if total, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			info.Disk = collectors.DiskInfo{Total: total}
+		}
+		return
+	}
+}
+
+// parsePciconfGPU reads display-class devices from `pciconf -lv`.
+func parsePciconfGPU(info *collectors.SystemInfo, output string) {
+	var vendor, device string
+	var display bool
+	flush := func() {
+		if display {
+			gpu := strings.TrimSpace(vendor + " " + device)
+			if gpu != "" {
+				info.GPU = append(info.GPU, gpu)
+			}
+		}
+		vendor, device, display = "", "", false
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			flush()
+			continue
+		}
+		idx := strings.Index(line, "=")
+		if idx == -1 {
+			continue
+		}
+		key := strings.TrimSpace(line[:idx])
+		value := strings.Trim(strings.TrimSpace(line[idx+1:]), "'")
+		switch key {
+		case "vendor":
+			vendor = value
+		case "device":
+			device = value
+		case "class":
+			display = value == "display"
+		}
+	}
+	flush()
+}
+
+// parseACPIBattery reads hw.acpi.battery.* sysctls. The state is a bitmask
+// where 2 means charging; time is in minutes and -1 when unknown.
+func parseACPIBattery(info *collectors.SystemInfo, output string) {
+	values := parseSysctlPairs(output)
+	life, err := strconv.ParseFloat(values["hw.acpi.battery.life"], 64)
+	if err != nil || life < 0 {
+		return
+	}
+
+	battery := collectors.BatteryInfo{
+		Present:    true,
+		Percentage: life,
+	}
+	if state, err := strconv.Atoi(values["hw.acpi.battery.state"]); err == nil {
+		battery.IsCharging = state&2 != 0
+	}
+	if minutes, err := strconv.Atoi(values["hw.acpi.battery.time"]); err == nil && minutes > 0 && !battery.IsCharging {
+		battery.TimeRemain = strconv.Itoa(minutes/60) + "h " + strconv.Itoa(minutes%60) + "m"
+	}
+	info.Battery = battery
+}
@@ -0,0 +1,188 @@
+package remote
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+func TestParseFreeBSDMemory(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   collectors.MemoryInfo
+	}{
+		{
+			name: "sysctl",
+			output: `hw.physmem: 8433876992
+hw.pagesize: 4096
+vm.stats.vm.v_free_count: 1000000
+vm.stats.vm.v_inactive_count: 200000
+vm.stats.vm.v_cache_count: 0
+`,
+			want: collectors.MemoryInfo{Total: 8433876992, Used: 8433876992 - 1200000*4096},
+		},
+		{
+			name: "no cache count",
+			output: `hw.physmem: 4294967296
+hw.pagesize: 4096
+vm.stats.vm.v_free_count: 262144
+vm.stats.vm.v_inactive_count: 0
+`,
+			want: collectors.MemoryInfo{Total: 4294967296, Used: 4294967296 - 262144*4096},
+		},
+		{
+			name: "available capped at total",
+			output: `hw.physmem: 4096
+hw.pagesize: 4096
+vm.stats.vm.v_free_count: 5
+`,
+			want: collectors.MemoryInfo{Total: 4096},
+		},
+		{
+			name:   "no physmem",
+			output: "hw.pagesize: 4096\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info collectors.SystemInfo
+			parseFreeBSDMemory(&info, tt.output)
+			if info.Memory != tt.want {
+				t.Errorf("Memory = %+v, want %+v", info.Memory, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGeomDisk(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   collectors.DiskInfo
+	}{
+		{
+			name: "geom disk list",
+			output: `Geom name: ada0
+Providers:
+1. Name: ada0
+   Mediasize: 256060514304 (238G)
+   Sectorsize: 512
+   Mode: r2w2e5
+   descr: Samsung SSD 850 PRO 256GB
+   ident: S39KNX0J123456
+   rotationrate: 0
+   fwsectors: 63
+   fwheads: 16
+
+Geom name: ada1
+Providers:
+1. Name: ada1
+   Mediasize: 1000204886016 (932G)
+`,
+			want: collectors.DiskInfo{Total: 256060514304},
+		},
+		{
+			name:   "no disks",
+			output: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info collectors.SystemInfo
+			parseGeomDisk(&info, tt.output)
+			if info.Disk != tt.want {
+				t.Errorf("Disk = %+v, want %+v", info.Disk, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePciconfGPU(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{
+			name: "integrated and network",
+			output: `hostb0@pci0:0:0:0:	class=0x060000 rev=0x06 hdr=0x00 vendor=0x8086 device=0x0c00 subvendor=0x1028 subdevice=0x05a4
+    vendor     = 'Intel Corporation'
+    device     = '4th Gen Core Processor DRAM Controller'
+    class      = bridge
+    subclass   = HOST-PCI
+vgapci0@pci0:0:2:0:	class=0x030000 rev=0x06 hdr=0x00 vendor=0x8086 device=0x0412 subvendor=0x1028 subdevice=0x05a4
+    vendor     = 'Intel Corporation'
+    device     = 'Xeon E3-1200 v3/4th Gen Core Processor Integrated Graphics Controller'
+    class      = display
+    subclass   = VGA
+em0@pci0:0:25:0:	class=0x020000 rev=0x04 hdr=0x00 vendor=0x8086 device=0x153a subvendor=0x1028 subdevice=0x05a4
+    vendor     = 'Intel Corporation'
+    device     = 'Ethernet Connection I217-LM'
+    class      = network
+    subclass   = ethernet
+`,
+			want: []string{"Intel Corporation Xeon E3-1200 v3/4th Gen Core Processor Integrated Graphics Controller"},
+		},
+		{
+			name: "discrete last",
+			output: `vgapci0@pci0:1:0:0:	class=0x030000 rev=0xa1 hdr=0x00 vendor=0x10de device=0x1c82 subvendor=0x1043 subdevice=0x8613
+    vendor     = 'NVIDIA Corporation'
+    device     = 'GP107 [GeForce GTX 1050 Ti]'
+    class      = display
+    subclass   = VGA`,
+			want: []string{"NVIDIA Corporation GP107 [GeForce GTX 1050 Ti]"},
+		},
+		{
+			name:   "no devices",
+			output: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info collectors.SystemInfo
+			parsePciconfGPU(&info, tt.output)
+			if !reflect.DeepEqual(info.GPU, tt.want) {
+				t.Errorf("GPU = %q, want %q", info.GPU, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseACPIBattery(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   collectors.BatteryInfo
+	}{
+		{
+			name:   "discharging",
+			output: "hw.acpi.battery.life: 76\nhw.acpi.battery.state: 1\nhw.acpi.battery.time: 185\n",
+			want:   collectors.BatteryInfo{Present: true, Percentage: 76, TimeRemain: "3h 5m"},
+		},
+		{
+			name:   "charging",
+			output: "hw.acpi.battery.life: 40\nhw.acpi.battery.state: 2\nhw.acpi.battery.time: -1\n",
+			want:   collectors.BatteryInfo{Present: true, Percentage: 40, IsCharging: true},
+		},
+		{
+			name:   "unknown time",
+			output: "hw.acpi.battery.life: 100\nhw.acpi.battery.state: 0\nhw.acpi.battery.time: -1\n",
+			want:   collectors.BatteryInfo{Present: true, Percentage: 100},
+		},
+		{
+			name:   "no battery",
+			output: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info collectors.SystemInfo
+			parseACPIBattery(&info, tt.output)
+			if info.Battery != tt.want {
+				t.Errorf("Battery = %+v, want %+v", info.Battery, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package remote
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+var linuxPlatform = remotePlatform{
+	name: "linux",
+	commands: []remoteCommand{
+		{"os", "cat /etc/os-release 2>/dev/null || cat /usr/lib/os-release 2>/dev/null || uname -s"},
+		{"kernel", "uname -r 2>/dev/null"},
+		{"hostname", "hostname 2>/dev/null || cat /etc/hostname 2>/dev/null"},
+		{"uptime", "cat /proc/uptime 2>/dev/null || uptime -p 2>/dev/null || uptime"},
+		{"cpu", "awk -F: '/model name/ {print $2; exit}' /proc/cpuinfo 2>/dev/null | xargs || lscpu 2>/dev/null | awk -F: '/Model name/ {print $2}'"},
+		{"memory", "awk '/MemTotal|MemAvailable/ {print}' /proc/meminfo 2>/dev/null"},
+		{"disk", "df -B1 / 2>/dev/null | awk 'NR==2 {print}'"},
+		{"shell", "echo $SHELL"},
+		{"term", "echo $TERM"},
+		{"de", "echo $XDG_CURRENT_DESKTOP"},
+		{"wm", "echo $XDG_SESSION_TYPE"},
+		{"gpu", "lspci 2>/dev/null | grep -iE 'vga|3d|display'"},
+		{"network", "ip -o addr show 2>/dev/null | grep -v 'lo' | grep 'inet '"},
+		{"battery", "cat /sys/class/power_supply/BAT*/capacity 2>/dev/null | head -1"},
+	},
+	safeCommands: []safeCommand{
+		{"os", []string{"cat /etc/os-release", "cat /usr/lib/os-release", "uname -s"}},
+		{"kernel", []string{"cat /proc/sys/kernel/osrelease", "uname -r"}},
+		{"hostname", []string{"cat /proc/sys/kernel/hostname", "cat /etc/hostname", "hostname"}},
+		{"uptime", []string{"cat /proc/uptime"}},
+		{"cpu", []string{"cat /proc/cpuinfo"}},
+		{"memory", []string{"cat /proc/meminfo"}},
+	},
+	parse: parseLinux,
+}
+
+func parseLinux(info *collectors.SystemInfo, results map[string]string) {
+	parseOS(info, results["os"])
+	info.Kernel = results["kernel"]
+	info.Hostname = results["hostname"]
+	parseUptime(info, results["uptime"])
+	parseCPU(info, results["cpu"])
+	parseMemory(info, results["memory"])
+	parseDisk(info, results["disk"])
+	info.Shell = results["shell"]
+	info.Terminal = results["term"]
+	info.DE = results["de"]
+	info.WM = results["wm"]
+	parseGPU(info, results["gpu"])
+	parseNetwork(info, results["network"])
+	parseBattery(info, results["battery"])
+}
+
+func parseCPU(info *collectors.SystemInfo, output string) {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "model name") {
+			parts := strings.SplitN(line, ":", 2)
+			if len(parts) == 2 {
+				info.CPU = strings.TrimSpace(parts[1])
+			}
+			return
+		}
+	}
+	if info.CPU == "" {
+		info.CPU = strings.TrimSpace(output)
+	}
+}
+
+func parseOS(info *collectors.SystemInfo, output string) {
+	if strings.Contains(output, "PRETTY_NAME") {
+		for _, line := range strings.Split(output, "\n") {
+			if strings.HasPrefix(line, "PRETTY_NAME=") {
+				info.OS = strings.Trim(strings.TrimPrefix(line, "PRETTY_NAME="), "\"")
+				return
+			}
+		}
+	}
+	info.OS = output
+}
+
+func parseUptime(info *collectors.SystemInfo, output string) {
+	if strings.Contains(output, " ") {
+		// Parse /proc/uptime format: "12345.67 12345.67"
+		parts := strings.Fields(output)
+		if len(parts) > 0 {
+			if seconds, err := strconv.ParseFloat(parts[0], 64); err == nil {
+				info.Uptime = formatUptime(uint64(seconds))
+				return
+			}
+		}
+	}
+	// Fallback to raw uptime output
+	info.Uptime = output
+}
+
+func parseMemory(info *collectors.SystemInfo, output string) {
+	var total, available uint64
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		value, _ := strconv.ParseUint(fields[1], 10, 64)
+		value *= 1024 // Convert from KB to bytes
+
+		if strings.HasPrefix(line, "MemTotal:") {
+			total = value
+		} else if strings.HasPrefix(line, "MemAvailable:") {
+			available = value
+		}
+	}
+
+	if total > 0 {
+		info.Memory = collectors.MemoryInfo{
+			Total: total,
+			Used:  total - available,
+		}
+	}
+}
+
+func parseDisk(info *collectors.SystemInfo, output string) {
+	fields := strings.Fields(output)
+	if len(fields) >= 3 {
+		total, _ := strconv.ParseUint(fields[1], 10, 64)
+		used, _ := strconv.ParseUint(fields[2], 10, 64)
+		info.Disk = collectors.DiskInfo{
+			Total: total,
+			Used:  used,
+		}
+	}
+}
+
+func parseGPU(info *collectors.SystemInfo, output string) {
+	lines := strings.Split(output, "\n")
+	for _, line := range lines {
+		clean := strings.TrimSpace(line)
+		if clean == "" {
+			continue
+		}
+
+		lower := strings.ToLower(clean)
+		var gpu string
+		if idx := strings.Index(lower, "controller:"); idx != -1 {
+			gpu = strings.TrimSpace(clean[idx+len("controller:"):])
+		} else if idx := strings.Index(clean, ":"); idx != -1 {
+			gpu = strings.TrimSpace(clean[idx+1:])
+		} else {
+			gpu = clean
+		}
+
+		gpu = strings.TrimPrefix(gpu, "VGA compatible controller: ")
+		gpu = strings.TrimPrefix(gpu, "3D controller: ")
+		gpu = strings.TrimPrefix(gpu, "Display controller: ")
+		if idx := strings.Index(gpu, " ("); idx != -1 {
+			gpu = strings.TrimSpace(gpu[:idx])
+		}
+		gpu = strings.Join(strings.Fields(gpu), " ")
+		info.GPU = append(info.GPU, gpu)
+	}
+}
+
+func parseNetwork(info *collectors.SystemInfo, output string) {
+	lines := strings.Split(output, "\n")
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) >= 4 {
+			iface := strings.TrimSuffix(fields[1], ":")
+			ip := strings.Split(fields[3], "/")[0]
+
+			netInfo := collectors.NetworkInfo{
+				Interface: iface,
+				IPv4:      ip,
+			}
+			info.Network = append(info.Network, netInfo)
+			if info.LocalIP == "" {
+				info.LocalIP = ip
+			}
+		}
+	}
+}
+
+func parseBattery(info *collectors.SystemInfo, output string) {
+	if output != "" {
+		if percentage, err := strconv.ParseFloat(output, 64); err == nil {
+			info.Battery = collectors.BatteryInfo{
+				Present:    true,
+				Percentage: percentage,
+			}
+		}
+	}
+}
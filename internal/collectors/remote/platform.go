@@ -0,0 +1,196 @@
+package remote
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+// remotePlatform groups the commands and parser used for one remote OS family.
+type remotePlatform struct {
+	name         string
+	commands     []remoteCommand
+	safeCommands []safeCommand
+	parse        func(info *collectors.SystemInfo, results map[string]string)
+}
+
+// remoteCommand is a shell command whose output is stored under name.
+type remoteCommand struct {
+	name string
+	cmd  string
+}
+
+// safeCommand lists read-only candidates tried in order until one succeeds.
+type safeCommand struct {
+	name string
+	cmds []string
+}
+
+// detectPlatform maps the output of `uname -s` to a command set.
+// Unknown kernels fall back to the Linux set.
+func detectPlatform(kernelName string) remotePlatform {
+	switch strings.ToLower(strings.TrimSpace(kernelName)) {
+	case "darwin":
+		return darwinPlatform
+	case "freebsd", "dragonfly", "openbsd", "netbsd":
+		// Other BSDs share most of the FreeBSD tooling; sysctls they lack
+		// simply leave the corresponding fields empty.
+		return freebsdPlatform
+	default:
+		return linuxPlatform
+	}
+}
+
+// parseDFKilobytes parses the data line of `df -k` output.
+func parseDFKilobytes(info *collectors.SystemInfo, output string) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] == "Filesystem" {
+			continue
+		}
+		total, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		used, _ := strconv.ParseUint(fields[2], 10, 64)
+		info.Disk = collectors.DiskInfo{
+			Total: total * 1024,
+			Used:  used * 1024,
+		}
+		return
+	}
+}
+
+// parseBootTime converts `sysctl -n kern.boottime` output, optionally followed
+// by a line with the remote `date +%s`, into an uptime string.
+func parseBootTime(info *collectors.SystemInfo, output string) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		return
+	}
+
+	boot, ok := parseBootSeconds(lines[0])
+	if !ok {
+		info.Uptime = strings.TrimSpace(lines[0])
+		return
+	}
+
+	now := time.Now().Unix()
+	if len(lines) > 1 {
+		if parsed, err := strconv.ParseInt(strings.TrimSpace(lines[len(lines)-1]), 10, 64); err == nil {
+			now = parsed
+		}
+	}
+	if now < boot {
+		return
+	}
+	info.Uptime = formatUptime(uint64(now - boot))
+}
+
+// parseBootSeconds accepts both "{ sec = 1700000000, usec = 0 } ..." and a
+// bare epoch value.
+func parseBootSeconds(value string) (int64, bool) {
+	value = strings.TrimSpace(value)
+	if idx := strings.Index(value, "sec ="); idx != -1 {
+		rest := strings.TrimSpace(value[idx+len("sec ="):])
+		if end := strings.IndexAny(rest, ", }"); end != -1 {
+			rest = rest[:end]
+		}
+		seconds, err := strconv.ParseInt(rest, 10, 64)
+		return seconds, err == nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	return seconds, err == nil
+}
+
+// parseSysctlPairs parses "name: value" or "name=value" lines from sysctl.
+func parseSysctlPairs(output string) map[string]string {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		idx := strings.IndexAny(line, ":=")
+		if idx <= 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:idx])
+		values[key] = strings.TrimSpace(line[idx+1:])
+	}
+	return values
+}
+
+// parseIfconfig extracts interfaces with an IPv4 address from BSD-style
+// `ifconfig` output, skipping loopback.
+func parseIfconfig(info *collectors.SystemInfo, output string) {
+	var current *collectors.NetworkInfo
+	flush := func() {
+		if current != nil && current.IPv4 != "" {
+			info.Network = append(info.Network, *current)
+			if info.LocalIP == "" {
+				info.LocalIP = current.IPv4
+			}
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			flush()
+			idx := strings.Index(line, ":")
+			if idx <= 0 {
+				continue
+			}
+			name := line[:idx]
+			if strings.HasPrefix(name, "lo") {
+				continue
+			}
+			current = &collectors.NetworkInfo{Interface: name}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "inet":
+			if current.IPv4 == "" {
+				current.IPv4 = fields[1]
+			}
+		case "inet6":
+			if current.IPv6 == "" {
+				current.IPv6 = strings.SplitN(fields[1], "%", 2)[0]
+			}
+		case "ether", "address:":
+			current.MAC = fields[1]
+		}
+	}
+	flush()
+}
+
+func formatUptime(seconds uint64) string {
+	duration := time.Duration(seconds) * time.Second
+	days := duration / (24 * time.Hour)
+	duration -= days * 24 * time.Hour
+	hours := duration / time.Hour
+	duration -= hours * time.Hour
+	minutes := duration / time.Minute
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	}
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
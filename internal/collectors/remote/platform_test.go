@@ -0,0 +1,131 @@
+package remote
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+func TestParseIfconfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		network []collectors.NetworkInfo
+		localIP string
+	}{
+		{
+			name: "freebsd",
+			output: `em0: flags=8843<UP,BROADCAST,RUNNING,SIMPLEX,MULTICAST> metric 0 mtu 1500
+	options=4e524bb<RXCSUM,TXCSUM,VLAN_MTU,VLAN_HWTAGGING,JUMBO_MTU,VLAN_HWCSUM,LRO,WOL_MAGIC,VLAN_HWFILTER,VLAN_HWTSO,RXCSUM_IPV6,TXCSUM_IPV6,HWSTATS,MEXTPG>
+	ether 00:25:90:aa:bb:cc
+	inet 192.168.1.10 netmask 0xffffff00 broadcast 192.168.1.255
+	inet6 fe80::225:90ff:feaa:bbcc%em0 prefixlen 64 scopeid 0x1
+	media: Ethernet autoselect (1000baseT <full-duplex>)
+	status: active
+	nd6 options=23<PERFORMNUD,ACCEPT_RTADV,AUTO_LINKLOCAL>
+lo0: flags=8049<UP,LOOPBACK,RUNNING,MULTICAST> metric 0 mtu 16384
+	options=680003<RXCSUM,TXCSUM,LINKSTATE,RXCSUM_IPV6,TXCSUM_IPV6>
+	inet 127.0.0.1 netmask 0xff000000
+	inet6 ::1 prefixlen 128
+em1: flags=8802<BROADCAST,SIMPLEX,MULTICAST> metric 0 mtu 1500
+	ether 00:25:90:aa:bb:cd
+	media: Ethernet autoselect
+	status: no carrier
+`,
+			network: []collectors.NetworkInfo{
+				{Interface: "em0", IPv4: "192.168.1.10", IPv6: "fe80::225:90ff:feaa:bbcc", MAC: "00:25:90:aa:bb:cc"},
+			},
+			localIP: "192.168.1.10",
+		},
+		{
+			name: "macos",
+			output: `lo0: flags=8049<UP,LOOPBACK,RUNNING,MULTICAST> mtu 16384
+	options=1203<RXCSUM,TXCSUM,TXSTATUS,SW_TIMESTAMP>
+	inet 127.0.0.1 netmask 0xff000000
+en0: flags=8863<UP,BROADCAST,SMART,RUNNING,SIMPLEX,MULTICAST> mtu 1500
+	options=6463<RXCSUM,TXCSUM,TSO4,TSO6,CHANNEL_IO,PARTIAL_CSUM,ZEROINVERT_CSUM>
+	ether a4:83:e7:11:22:33
+	inet6 fe80::1c2f:5a3b:9d4e:7f60%en0 prefixlen 64 secured scopeid 0x6
+	inet 10.0.0.5 netmask 0xffffff00 broadcast 10.0.0.255
+	nd6 options=201<PERFORMNUD,DAD>
+	media: autoselect
+	status: active
+utun0: flags=8051<UP,POINTOPOINT,RUNNING,MULTICAST> mtu 1380
+	inet6 fe80::ce81:b1c:bd2c:69e%utun0 prefixlen 64 scopeid 0xf
+en5: flags=8863<UP,BROADCAST,SMART,RUNNING,SIMPLEX,MULTICAST> mtu 1500
+	ether ac:de:48:00:11:22
+	inet 192.168.64.1 netmask 0xffffff00 broadcast 192.168.64.255
+`,
+			network: []collectors.NetworkInfo{
+				{Interface: "en0", IPv4: "10.0.0.5", IPv6: "fe80::1c2f:5a3b:9d4e:7f60", MAC: "a4:83:e7:11:22:33"},
+				{Interface: "en5", IPv4: "192.168.64.1", MAC: "ac:de:48:00:11:22"},
+			},
+			localIP: "10.0.0.5",
+		},
+		{
+			name:   "loopback only",
+			output: "lo0: flags=8049<UP,LOOPBACK,RUNNING,MULTICAST> mtu 16384\n\tinet 127.0.0.1 netmask 0xff000000\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info collectors.SystemInfo
+			parseIfconfig(&info, tt.output)
+			if !reflect.DeepEqual(info.Network, tt.network) {
+				t.Errorf("Network = %+v, want %+v", info.Network, tt.network)
+			}
+			if info.LocalIP != tt.localIP {
+				t.Errorf("LocalIP = %q, want %q", info.LocalIP, tt.localIP)
+			}
+		})
+	}
+}
+
+func TestParseBootTime(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "kern.boottime struct",
+			output: "{ sec = 1700000000, usec = 123456 } Tue Nov 14 22:13:20 2023\n1700090061\n",
+			want:   "1d 1h 1m",
+		},
+		{
+			name:   "epoch",
+			output: "1700000000\n1700003720\n",
+			want:   "1h 2m",
+		},
+		{
+			name:   "minutes",
+			output: "{ sec = 1700000000, usec = 0 } Tue Nov 14 22:13:20 2023\n1700000300\n",
+			want:   "5m",
+		},
+		{
+			name:   "clock behind boot time",
+			output: "1700000000\n1600000000\n",
+			want:   "",
+		},
+		{
+			name:   "unparsed",
+			output: "up 3 days\n",
+			want:   "up 3 days",
+		},
+		{
+			name:   "no output",
+			output: "",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info collectors.SystemInfo
+			parseBootTime(&info, tt.output)
+			if info.Uptime != tt.want {
+				t.Errorf("Uptime = %q, want %q", info.Uptime, tt.want)
+			}
+		})
+	}
+}
@@ -11,27 +11,28 @@ import (
 	"strings"
 	"time"
 
-	"github.com/howieduhzit/bubblefetch/internal/collectors"
 	"github.com/howieduhzit/bubblefetch/internal/config"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/crypto/ssh/knownhosts"
 )
 
-type SSHCollector struct {
+// SSHTransport runs commands on a remote host over SSH.
+type SSHTransport struct {
 	host   string
 	config *config.Config
 	client *ssh.Client
 }
 
-func New(host string, cfg *config.Config) *SSHCollector {
-	return &SSHCollector{
+// NewSSH creates a transport for host, which may be "user@host[:port]".
+func NewSSH(host string, cfg *config.Config) *SSHTransport {
+	return &SSHTransport{
 		host:   host,
 		config: cfg,
 	}
 }
 
-func (c *SSHCollector) Connect() error {
+func (c *SSHTransport) Connect() error {
 	// Determine user
 	user := c.config.SSH.User
 	if user == "" {
@@ -315,338 +316,47 @@ func buildHostKeyCallback(knownHostsPath string) (ssh.HostKeyCallback, error) {
 	return ssh.InsecureIgnoreHostKey(), nil
 }
 
-func (c *SSHCollector) Collect() (*collectors.SystemInfo, error) {
+// Run executes cmd through the remote shell wrapper.
+func (c *SSHTransport) Run(cmd string) (string, error) {
+	return c.run(wrapRemoteCommand(cmd))
+}
+
+// RunRaw executes cmd without the shell wrapper.
+func (c *SSHTransport) RunRaw(cmd string) (string, error) {
+	return c.run(cmd)
+}
+
+func (c *SSHTransport) run(cmd string) (string, error) {
 	if c.client == nil {
 		if err := c.Connect(); err != nil {
-			return nil, err
-		}
-		defer c.client.Close()
-	}
-
-	if c.config != nil && c.config.SSH.SafeMode {
-		return c.collectSafe()
-	}
-
-	info := &collectors.SystemInfo{}
-	costs := make(map[string]time.Duration)
-
-	// Run commands in parallel
-	type cmdResult struct {
-		name   string
-		output string
-		err    error
-	}
-
-	commands := []struct {
-		name string
-		cmd  string
-	}{
-		{"os", "cat /etc/os-release 2>/dev/null || cat /usr/lib/os-release 2>/dev/null || uname -s"},
-		{"kernel", "uname -r 2>/dev/null"},
-		{"hostname", "hostname 2>/dev/null || cat /etc/hostname 2>/dev/null"},
-		{"uptime", "cat /proc/uptime 2>/dev/null || uptime -p 2>/dev/null || uptime"},
-		{"cpu", "awk -F: '/model name/ {print $2; exit}' /proc/cpuinfo 2>/dev/null | xargs || lscpu 2>/dev/null | awk -F: '/Model name/ {print $2}'"},
-		{"memory", "awk '/MemTotal|MemAvailable/ {print}' /proc/meminfo 2>/dev/null"},
-		{"disk", "df -B1 / 2>/dev/null | awk 'NR==2 {print}'"},
-		{"shell", "echo $SHELL"},
-		{"term", "echo $TERM"},
-		{"de", "echo $XDG_CURRENT_DESKTOP"},
-		{"wm", "echo $XDG_SESSION_TYPE"},
-		{"gpu", "lspci 2>/dev/null | grep -iE 'vga|3d|display'"},
-		{"network", "ip -o addr show 2>/dev/null | grep -v 'lo' | grep 'inet '"},
-		{"battery", "cat /sys/class/power_supply/BAT*/capacity 2>/dev/null | head -1"},
-	}
-
-	results := make(map[string]string)
-	for _, cmd := range commands {
-		start := time.Now()
-		output, err := c.runCommand(cmd.cmd)
-		costs[cmd.name] = time.Since(start)
-		clean := strings.TrimSpace(output)
-		if clean != "" || err == nil {
-			results[cmd.name] = clean
+			return "", err
 		}
 	}
 
-	// Parse results
-	c.parseOS(info, results["os"])
-	info.Kernel = results["kernel"]
-	info.Hostname = results["hostname"]
-	c.parseUptime(info, results["uptime"])
-	info.CPU = results["cpu"]
-	c.parseMemory(info, results["memory"])
-	c.parseDisk(info, results["disk"])
-	info.Shell = results["shell"]
-	info.Terminal = results["term"]
-	info.DE = results["de"]
-	info.WM = results["wm"]
-	c.parseGPU(info, results["gpu"])
-	c.parseNetwork(info, results["network"])
-	c.parseBattery(info, results["battery"])
-	addRemoteCosts(info, costs)
-
-	return info, nil
-}
-
-func (c *SSHCollector) runCommand(cmd string) (string, error) {
 	session, err := c.client.NewSession()
 	if err != nil {
 		return "", err
 	}
 	defer session.Close()
 
-	output, err := session.CombinedOutput(wrapRemoteCommand(cmd))
+	output, err := session.CombinedOutput(cmd)
 	return string(output), err
 }
 
-func (c *SSHCollector) runCommandRaw(cmd string) (string, error) {
-	session, err := c.client.NewSession()
-	if err != nil {
-		return "", err
+// Close closes the SSH connection if one is open.
+func (c *SSHTransport) Close() error {
+	if c.client == nil {
+		return nil
 	}
-	defer session.Close()
-
-	output, err := session.CombinedOutput(cmd)
-	return string(output), err
+	err := c.client.Close()
+	c.client = nil
+	return err
 }
 
 func wrapRemoteCommand(cmd string) string {
-	prefix := "PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin; LC_ALL=C; "
-	return "/bin/sh -lc '" + escapeSingleQuotes(prefix+cmd) + "'"
+	return "/bin/sh -lc '" + escapeSingleQuotes(remoteShellPrefix+cmd) + "'"
 }
 
 func escapeSingleQuotes(input string) string {
 	return strings.ReplaceAll(input, "'", "'\"'\"'")
 }
-
-func (c *SSHCollector) collectSafe() (*collectors.SystemInfo, error) {
-	info := &collectors.SystemInfo{}
-	costs := make(map[string]time.Duration)
-
-	first := func(name string, cmds ...string) string {
-		for _, cmd := range cmds {
-			start := time.Now()
-			out, err := c.runCommandRaw(cmd)
-			costs[name] += time.Since(start)
-			if err != nil {
-				continue
-			}
-			clean := strings.TrimSpace(out)
-			if clean != "" {
-				return clean
-			}
-		}
-		return ""
-	}
-
-	info.OS = c.parseOSValue(first("os", "cat /etc/os-release", "cat /usr/lib/os-release", "uname -s"))
-	info.Kernel = first("kernel", "cat /proc/sys/kernel/osrelease", "uname -r")
-	info.Hostname = first("hostname", "cat /proc/sys/kernel/hostname", "cat /etc/hostname", "hostname")
-	c.parseUptime(info, first("uptime", "cat /proc/uptime"))
-	c.parseCPU(info, first("cpu", "cat /proc/cpuinfo"))
-	c.parseMemory(info, first("memory", "cat /proc/meminfo"))
-	addRemoteCosts(info, costs)
-
-	return info, nil
-}
-
-func (c *SSHCollector) parseOSValue(output string) string {
-	info := &collectors.SystemInfo{}
-	c.parseOS(info, output)
-	return info.OS
-}
-
-func (c *SSHCollector) parseCPU(info *collectors.SystemInfo, output string) {
-	for _, line := range strings.Split(output, "\n") {
-		if strings.HasPrefix(line, "model name") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				info.CPU = strings.TrimSpace(parts[1])
-			}
-			return
-		}
-	}
-	if info.CPU == "" {
-		info.CPU = strings.TrimSpace(output)
-	}
-}
-
-func addRemoteCosts(info *collectors.SystemInfo, costs map[string]time.Duration) {
-	if info == nil {
-		return
-	}
-	for key, duration := range costs {
-		switch key {
-		case "os":
-			collectors.AddModuleCost(info, "OS", duration)
-		case "kernel":
-			collectors.AddModuleCost(info, "Kernel", duration)
-		case "hostname":
-			collectors.AddModuleCost(info, "Host", duration)
-		case "uptime":
-			collectors.AddModuleCost(info, "Uptime", duration)
-		case "cpu":
-			collectors.AddModuleCost(info, "CPU", duration)
-		case "memory":
-			collectors.AddModuleCost(info, "Memory", duration)
-		case "disk":
-			collectors.AddModuleCost(info, "Disk", duration)
-		case "shell":
-			collectors.AddModuleCost(info, "Shell", duration)
-		case "term":
-			collectors.AddModuleCost(info, "Terminal", duration)
-		case "de":
-			collectors.AddModuleCost(info, "DE", duration)
-		case "wm":
-			collectors.AddModuleCost(info, "WM", duration)
-		case "gpu":
-			collectors.AddModuleCost(info, "GPU", duration)
-		case "network":
-			collectors.AddModuleCost(info, "Network", duration)
-			collectors.AddModuleCost(info, "Local IP", duration)
-		case "battery":
-			collectors.AddModuleCost(info, "Battery", duration)
-		}
-	}
-}
-
-func (c *SSHCollector) parseOS(info *collectors.SystemInfo, output string) {
-	if strings.Contains(output, "PRETTY_NAME") {
-		for _, line := range strings.Split(output, "\n") {
-			if strings.HasPrefix(line, "PRETTY_NAME=") {
-				info.OS = strings.Trim(strings.TrimPrefix(line, "PRETTY_NAME="), "\"")
-				return
-			}
-		}
-	}
-	info.OS = output
-}
-
-func (c *SSHCollector) parseUptime(info *collectors.SystemInfo, output string) {
-	if strings.Contains(output, " ") {
-		// Parse /proc/uptime format: "12345.67 12345.67"
-		parts := strings.Fields(output)
-		if len(parts) > 0 {
-			if seconds, err := strconv.ParseFloat(parts[0], 64); err == nil {
-				info.Uptime = formatUptime(uint64(seconds))
-				return
-			}
-		}
-	}
-	// Fallback to raw uptime output
-	info.Uptime = output
-}
-
-func (c *SSHCollector) parseMemory(info *collectors.SystemInfo, output string) {
-	var total, available uint64
-	for _, line := range strings.Split(output, "\n") {
-		fields := strings.Fields(line)
-		if len(fields) < 2 {
-			continue
-		}
-		value, _ := strconv.ParseUint(fields[1], 10, 64)
-		value *= 1024 // Convert from KB to bytes
-
-		if strings.HasPrefix(line, "MemTotal:") {
-			total = value
-		} else if strings.HasPrefix(line, "MemAvailable:") {
-			available = value
-		}
-	}
-
-	if total > 0 {
-		info.Memory = collectors.MemoryInfo{
-			Total: total,
-			Used:  total - available,
-		}
-	}
-}
-
-func (c *SSHCollector) parseDisk(info *collectors.SystemInfo, output string) {
-	fields := strings.Fields(output)
-	if len(fields) >= 3 {
-		total, _ := strconv.ParseUint(fields[1], 10, 64)
-		used, _ := strconv.ParseUint(fields[2], 10, 64)
-		info.Disk = collectors.DiskInfo{
-			Total: total,
-			Used:  used,
-		}
-	}
-}
-
-func (c *SSHCollector) parseGPU(info *collectors.SystemInfo, output string) {
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		clean := strings.TrimSpace(line)
-		if clean == "" {
-			continue
-		}
-
-		lower := strings.ToLower(clean)
-		var gpu string
-		if idx := strings.Index(lower, "controller:"); idx != -1 {
-			gpu = strings.TrimSpace(clean[idx+len("controller:"):])
-		} else if idx := strings.Index(clean, ":"); idx != -1 {
-			gpu = strings.TrimSpace(clean[idx+1:])
-		} else {
-			gpu = clean
-		}
-
-		gpu = strings.TrimPrefix(gpu, "VGA compatible controller: ")
-		gpu = strings.TrimPrefix(gpu, "3D controller: ")
-		gpu = strings.TrimPrefix(gpu, "Display controller: ")
-		if idx := strings.Index(gpu, " ("); idx != -1 {
-			gpu = strings.TrimSpace(gpu[:idx])
-		}
-		gpu = strings.Join(strings.Fields(gpu), " ")
-		info.GPU = append(info.GPU, gpu)
-	}
-}
-
-func (c *SSHCollector) parseNetwork(info *collectors.SystemInfo, output string) {
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		fields := strings.Fields(line)
-		if len(fields) >= 4 {
-			iface := strings.TrimSuffix(fields[1], ":")
-			ip := strings.Split(fields[3], "/")[0]
-
-			netInfo := collectors.NetworkInfo{
-				Interface: iface,
-				IPv4:      ip,
-			}
-			info.Network = append(info.Network, netInfo)
-			if info.LocalIP == "" {
-				info.LocalIP = ip
-			}
-		}
-	}
-}
-
-func (c *SSHCollector) parseBattery(info *collectors.SystemInfo, output string) {
-	if output != "" {
-		if percentage, err := strconv.ParseFloat(output, 64); err == nil {
-			info.Battery = collectors.BatteryInfo{
-				Present:    true,
-				Percentage: percentage,
-			}
-		}
-	}
-}
-
-func formatUptime(seconds uint64) string {
-	duration := time.Duration(seconds) * time.Second
-	days := duration / (24 * time.Hour)
-	duration -= days * 24 * time.Hour
-	hours := duration / time.Hour
-	duration -= hours * time.Hour
-	minutes := duration / time.Minute
-
-	if days > 0 {
-		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
-	}
-	if hours > 0 {
-		return fmt.Sprintf("%dh %dm", hours, minutes)
-	}
-	return fmt.Sprintf("%dm", minutes)
-}
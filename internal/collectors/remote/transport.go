@@ -0,0 +1,232 @@
+package remote
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+)
+
+// remoteShellPrefix pins PATH and locale so command output parses consistently.
+const remoteShellPrefix = "PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin; LC_ALL=C; "
+
+// Transport runs commands on a collection target.
+type Transport interface {
+	// Connect prepares the transport; it is called once before any command.
+	Connect() error
+	// Run executes cmd through a shell with a pinned PATH and locale.
+	Run(cmd string) (string, error)
+	// RunRaw executes cmd as-is, used by safe mode.
+	RunRaw(cmd string) (string, error)
+	Close() error
+}
+
+// Collector gathers system info from a target through a Transport, using the
+// command set that matches the target's platform.
+type Collector struct {
+	transport Transport
+	safe      bool
+}
+
+// New creates a collector for target. Plain hosts ("host", "user@host:port")
+// and "ssh://" targets use SSH; other schemes are:
+//
+//	docker://container
+//	podman://container
+//	k8s://namespace/pod[/container]
+//	machinectl://machine
+//	chroot:///path/to/root
+func New(target string, cfg *config.Config) *Collector {
+	safe := cfg != nil && cfg.SSH.SafeMode
+	transport, err := NewTransport(target, cfg)
+	if err != nil {
+		return &Collector{transport: failedTransport{err: err}, safe: safe}
+	}
+	return &Collector{transport: transport, safe: safe}
+}
+
+// NewTransport parses a target URI and returns the matching transport.
+func NewTransport(target string, cfg *config.Config) (Transport, error) {
+	scheme, rest := splitTarget(target)
+	if rest == "" {
+		return nil, fmt.Errorf("invalid remote target %q", target)
+	}
+
+	switch scheme {
+	case "", "ssh":
+		return NewSSH(rest, cfg), nil
+	case "docker", "podman":
+		return NewExec(scheme, []string{scheme, "exec", rest}), nil
+	case "k8s", "kubectl":
+		parts := strings.Split(rest, "/")
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid kubernetes target %q (use k8s://namespace/pod[/container])", target)
+		}
+		args := []string{"kubectl", "exec", "-n", parts[0], parts[1]}
+		if len(parts) > 2 && parts[2] != "" {
+			args = append(args, "-c", parts[2])
+		}
+		return NewExec("kubectl", append(args, "--")), nil
+	case "machinectl", "nspawn":
+		return NewExec("machinectl", []string{"machinectl", "-q", "shell", rest}), nil
+	case "chroot":
+		if !strings.HasPrefix(rest, "/") {
+			rest = "/" + rest
+		}
+		return NewExec("chroot", []string{"chroot", rest}), nil
+	default:
+		return nil, fmt.Errorf("unsupported remote transport %q", scheme)
+	}
+}
+
+// TransportName returns the canonical transport name for target ("ssh",
+// "docker", "podman", "kubectl", "machinectl" or "chroot").
+func TransportName(target string) string {
+	scheme, _ := splitTarget(target)
+	switch scheme {
+	case "", "ssh":
+		return "ssh"
+	case "k8s", "kubectl":
+		return "kubectl"
+	case "machinectl", "nspawn":
+		return "machinectl"
+	default:
+		return scheme
+	}
+}
+
+// splitTarget separates "scheme://rest". Targets without a scheme return an
+// empty scheme and the input unchanged.
+func splitTarget(target string) (string, string) {
+	target = strings.TrimSpace(target)
+	idx := strings.Index(target, "://")
+	if idx == -1 {
+		return "", target
+	}
+	return strings.ToLower(target[:idx]), target[idx+len("://"):]
+}
+
+func (c *Collector) Collect() (*collectors.SystemInfo, error) {
+	if err := c.transport.Connect(); err != nil {
+		return nil, err
+	}
+	defer c.transport.Close()
+
+	// Probe the kernel name first so the right command set is used.
+	start := time.Now()
+	var kernelName string
+	if c.safe {
+		kernelName, _ = c.transport.RunRaw("uname -s")
+	} else {
+		kernelName, _ = c.transport.Run("uname -s")
+	}
+	probeDuration := time.Since(start)
+	platform := detectPlatform(kernelName)
+
+	if c.safe {
+		return c.collectSafe(platform, probeDuration)
+	}
+
+	info := &collectors.SystemInfo{}
+	costs := map[string]time.Duration{"os": probeDuration}
+
+	results := make(map[string]string)
+	for _, cmd := range platform.commands {
+		start := time.Now()
+		output, err := c.transport.Run(cmd.cmd)
+		costs[cmd.name] += time.Since(start)
+		clean := strings.TrimSpace(output)
+		if clean != "" || err == nil {
+			results[cmd.name] = clean
+		}
+	}
+
+	platform.parse(info, results)
+	addRemoteCosts(info, costs)
+
+	return info, nil
+}
+
+func (c *Collector) collectSafe(platform remotePlatform, probeDuration time.Duration) (*collectors.SystemInfo, error) {
+	info := &collectors.SystemInfo{}
+	costs := map[string]time.Duration{"os": probeDuration}
+
+	first := func(name string, cmds ...string) string {
+		for _, cmd := range cmds {
+			start := time.Now()
+			out, err := c.transport.RunRaw(cmd)
+			costs[name] += time.Since(start)
+			if err != nil {
+				continue
+			}
+			clean := strings.TrimSpace(out)
+			if clean != "" {
+				return clean
+			}
+		}
+		return ""
+	}
+
+	results := make(map[string]string)
+	for _, cmd := range platform.safeCommands {
+		if out := first(cmd.name, cmd.cmds...); out != "" {
+			results[cmd.name] = out
+		}
+	}
+
+	platform.parse(info, results)
+	addRemoteCosts(info, costs)
+
+	return info, nil
+}
+
+func addRemoteCosts(info *collectors.SystemInfo, costs map[string]time.Duration) {
+	if info == nil {
+		return
+	}
+	for key, duration := range costs {
+		switch key {
+		case "os":
+			collectors.AddModuleCost(info, "OS", duration)
+		case "kernel":
+			collectors.AddModuleCost(info, "Kernel", duration)
+		case "hostname":
+			collectors.AddModuleCost(info, "Host", duration)
+		case "uptime":
+			collectors.AddModuleCost(info, "Uptime", duration)
+		case "cpu":
+			collectors.AddModuleCost(info, "CPU", duration)
+		case "memory":
+			collectors.AddModuleCost(info, "Memory", duration)
+		case "disk":
+			collectors.AddModuleCost(info, "Disk", duration)
+		case "shell":
+			collectors.AddModuleCost(info, "Shell", duration)
+		case "term":
+			collectors.AddModuleCost(info, "Terminal", duration)
+		case "de":
+			collectors.AddModuleCost(info, "DE", duration)
+		case "wm":
+			collectors.AddModuleCost(info, "WM", duration)
+		case "gpu":
+			collectors.AddModuleCost(info, "GPU", duration)
+		case "network":
+			collectors.AddModuleCost(info, "Network", duration)
+			collectors.AddModuleCost(info, "Local IP", duration)
+		case "battery":
+			collectors.AddModuleCost(info, "Battery", duration)
+		}
+	}
+}
+
+// failedTransport reports a target parsing error from Connect.
+type failedTransport struct {
+	err error
+}
+
+func (t failedTransport) Connect() error                { return t.err }
+func (t failedTransport) Run(string) (string, error)    { return "", t.err }
+func (t failedTransport) RunRaw(string) (string, error) { return "", t.err }
+func (t failedTransport) Close() error                  { return nil }
@@ -9,14 +9,51 @@ import (
 )
 
 type Config struct {
-	Theme                   string    `yaml:"theme"`
-	Remote                  string    `yaml:"remote"`
-	Modules                 []string  `yaml:"modules"`
-	SSH                     SSHConfig `yaml:"ssh"`
-	EnablePublicIP          bool      `yaml:"enable_public_ip"`
-	PluginDir               string    `yaml:"plugin_dir"`
-	ExternalModuleTimeoutMS int       `yaml:"external_module_timeout_ms"`
-	BagsAPIKey              string    `yaml:"bags_api_key"` // Optional Bags.fm API key for enhanced Solana token data
+	Theme                   string                    `yaml:"theme"`
+	Color                   string                    `yaml:"color"`         // auto, always, or never
+	Background              string                    `yaml:"background"`    // auto, light, or dark
+	Logo                    string                    `yaml:"logo"`          // Image shown instead of the ASCII art, or auto for the distro's
+	LogoProtocol            string                    `yaml:"logo_protocol"` // auto, kitty, sixel, iterm, or blocks
+	Remote                  string                    `yaml:"remote"`
+	Modules                 []ModuleConfig            `yaml:"modules"`
+	SSH                     SSHConfig                 `yaml:"ssh"`
+	Serve                   ServeConfig               `yaml:"serve"`
+	Image                   ImageConfig               `yaml:"image"`
+	Layout                  LayoutConfig              `yaml:"layout,omitempty"`
+	EnablePublicIP          bool                      `yaml:"enable_public_ip"`
+	PluginDir               string                    `yaml:"plugin_dir"`
+	ExternalModuleTimeoutMS int                       `yaml:"external_module_timeout_ms"`
+	PluginConfig            map[string]map[string]any `yaml:"plugin_config"` // Settings for RPC plugins, by plugin name
+	BagsAPIKey              string                    `yaml:"bags_api_key"`  // Optional Bags.fm API key for enhanced Solana token data
+}
+
+// ImageConfig controls --image-export rendering.
+type ImageConfig struct {
+	Scale float64 `yaml:"scale"` // Pixel scale, e.g. 2 for HiDPI (default 1)
+	Font  string  `yaml:"font"`  // Fallback font for icons, e.g. a Nerd Font (.ttf)
+	// Animated exports (gif, animated svg)
+	Frames          int `yaml:"frames"`            // Number of samples (default 10)
+	FrameIntervalMS int `yaml:"frame_interval_ms"` // Time between samples and frame duration (default 1000)
+}
+
+// LayoutConfig overrides the theme's layout of the fetch view. Unset fields
+// keep the theme's values.
+type LayoutConfig struct {
+	ASCIIPosition string          `yaml:"ascii_position,omitempty"` // left, right, top, or hidden
+	Columns       int             `yaml:"columns,omitempty"`        // Module grid columns
+	Sections      []SectionConfig `yaml:"sections,omitempty"`       // Titled module groups; replace modules in the fetch view
+	Header        []string        `yaml:"header,omitempty"`         // Lines above the modules, e.g. "{title}"
+	Footer        []string        `yaml:"footer,omitempty"`         // Lines below the modules, e.g. "{colors}"
+	// Fitting the output to the terminal
+	Width         int               `yaml:"width,omitempty"`           // Render for this many columns (default: terminal width)
+	MinASCIIWidth int               `yaml:"min_ascii_width,omitempty"` // Hide the ASCII art on narrower terminals
+	Overflow      map[string]string `yaml:"overflow,omitempty"`        // Per-module truncate or wrap, e.g. {gpu: truncate}
+}
+
+// SectionConfig is a titled group of modules.
+type SectionConfig struct {
+	Title   string         `yaml:"title"`
+	Modules []ModuleConfig `yaml:"modules"`
 }
 
 type SSHConfig struct {
@@ -27,6 +64,11 @@ type SSHConfig struct {
 	SafeMode       bool   `yaml:"safe_mode"`
 }
 
+type ServeConfig struct {
+	Token string   `yaml:"token"` // Bearer token required by serve mode (empty disables auth)
+	Allow []string `yaml:"allow"` // Client IPs or CIDRs allowed to connect (empty allows all)
+}
+
 // Load loads configuration from the specified path or default location
 func Load(configPath string) (*Config, error) {
 	if configPath == "" {
@@ -62,13 +104,59 @@ func Load(configPath string) (*Config, error) {
 	if cfg.ExternalModuleTimeoutMS == 0 {
 		cfg.ExternalModuleTimeoutMS = 250
 	}
+	if len(cfg.Modules) == 0 {
+		cfg.Modules = cfg.Layout.sectionModules()
+	}
 	if len(cfg.Modules) == 0 {
 		cfg.Modules = defaultModules()
 	}
+	if err := validateModules("modules", cfg.Modules); err != nil {
+		return nil, err
+	}
+	if err := cfg.Layout.validate(); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
 
+// sectionModules returns the modules of all sections, in order.
+func (l LayoutConfig) sectionModules() []ModuleConfig {
+	var mods []ModuleConfig
+	for _, section := range l.Sections {
+		mods = append(mods, section.Modules...)
+	}
+	return mods
+}
+
+func (l LayoutConfig) validate() error {
+	switch l.ASCIIPosition {
+	case "", "left", "right", "top", "hidden":
+	default:
+		return fmt.Errorf("layout.ascii_position: invalid value %q (use left, right, top, or hidden)", l.ASCIIPosition)
+	}
+	if l.Columns < 0 {
+		return fmt.Errorf("layout.columns: must not be negative")
+	}
+	if l.Width < 0 {
+		return fmt.Errorf("layout.width: must not be negative")
+	}
+	if l.MinASCIIWidth < 0 {
+		return fmt.Errorf("layout.min_ascii_width: must not be negative")
+	}
+	for i, section := range l.Sections {
+		if err := validateModules(fmt.Sprintf("layout.sections[%d].modules", i), section.Modules); err != nil {
+			return err
+		}
+	}
+	for name, overflow := range l.Overflow {
+		if overflow != "truncate" && overflow != "wrap" {
+			return fmt.Errorf("layout.overflow.%s: invalid value %q (use truncate or wrap)", name, overflow)
+		}
+	}
+	return nil
+}
+
 func defaultConfig() *Config {
 	return &Config{
 		Theme:   "default",
@@ -113,8 +201,8 @@ func Save(cfg *Config) error {
 	return nil
 }
 
-func defaultModules() []string {
-	return []string{
+func defaultModules() []ModuleConfig {
+	return Modules(
 		"os",
 		"kernel",
 		"hostname",
@@ -129,5 +217,5 @@ func defaultModules() []string {
 		"wm",
 		"localip",
 		"battery",
-	}
+	)
 }
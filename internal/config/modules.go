@@ -0,0 +1,212 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ModuleConfig is one entry of the modules list. In YAML it is either a
+// module name or an object naming the module and how to present it:
+//
+//	modules:
+//	  - os
+//	  - name: memory
+//	    label: RAM
+//	    format: "{used} / {total} ({percent}%)"
+//	    unit: GB
+//
+// An entry with type custom defines a module of its own, whose value is a Go
+// text/template over the collected system information:
+//
+//	modules:
+//	  - name: ram
+//	    type: custom
+//	    label: RAM
+//	    template: "{{.Memory.Used | bytes}} of {{.Memory.Total | bytes}}"
+type ModuleConfig struct {
+	Name     string     `yaml:"name"`
+	Type     string     `yaml:"type,omitempty"`     // custom for a template module; empty for built-in modules and plugins
+	Label    string     `yaml:"label,omitempty"`    // Replaces the module's label
+	Icon     string     `yaml:"icon,omitempty"`     // Replaces the label's icon; "none" hides it
+	Format   string     `yaml:"format,omitempty"`   // Value with {placeholders}, e.g. "{used} of {total}"
+	Unit     string     `yaml:"unit,omitempty"`     // Unit for sizes: B, KiB..TiB, KB..TB (default: automatic binary)
+	Template string     `yaml:"template,omitempty"` // Value of a custom module
+	Bar      *BarConfig `yaml:"bar,omitempty"`      // Usage bar after the memory, disk or battery value
+}
+
+// BarConfig is a usage bar. In YAML, bar: true draws one with the defaults.
+type BarConfig struct {
+	Width    int     `yaml:"width,omitempty"`    // Cells (default 10)
+	Glyphs   string  `yaml:"glyphs,omitempty"`   // blocks (default), braille, or ascii
+	Warning  float64 `yaml:"warning,omitempty"`  // Percent at which the bar turns the warning color
+	Critical float64 `yaml:"critical,omitempty"` // Percent at which it turns the critical color
+	off      bool
+}
+
+// Enabled reports whether a bar is drawn. bar: false turns it off.
+func (b *BarConfig) Enabled() bool {
+	return b != nil && !b.off
+}
+
+// barConfigFields has the same fields as BarConfig without its YAML methods.
+type barConfigFields struct {
+	Width    int     `yaml:"width,omitempty"`
+	Glyphs   string  `yaml:"glyphs,omitempty"`
+	Warning  float64 `yaml:"warning,omitempty"`
+	Critical float64 `yaml:"critical,omitempty"`
+}
+
+// UnmarshalYAML accepts true, false or an object.
+func (b *BarConfig) UnmarshalYAML(node *yaml.Node) error {
+	*b = BarConfig{}
+	if node.Kind == yaml.ScalarNode {
+		var on bool
+		if err := node.Decode(&on); err != nil {
+			return err
+		}
+		b.off = !on
+		return nil
+	}
+	var fields barConfigFields
+	if err := node.Decode(&fields); err != nil {
+		return err
+	}
+	b.Width, b.Glyphs, b.Warning, b.Critical = fields.Width, fields.Glyphs, fields.Warning, fields.Critical
+	return nil
+}
+
+// MarshalYAML writes bars with default settings as true or false.
+func (b BarConfig) MarshalYAML() (interface{}, error) {
+	if b.off {
+		return false, nil
+	}
+	fields := barConfigFields{Width: b.Width, Glyphs: b.Glyphs, Warning: b.Warning, Critical: b.Critical}
+	if fields == (barConfigFields{}) {
+		return true, nil
+	}
+	return fields, nil
+}
+
+// ModuleTypeCustom is the type of modules defined by a template.
+const ModuleTypeCustom = "custom"
+
+// ByteUnits are the units accepted by the unit option, with their size in
+// bytes.
+var ByteUnits = map[string]uint64{
+	"B":   1,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+}
+
+// moduleConfigFields has the same fields as ModuleConfig without its YAML
+// methods.
+type moduleConfigFields ModuleConfig
+
+// UnmarshalYAML accepts a module name or an object.
+func (m *ModuleConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*m = ModuleConfig{}
+		return node.Decode(&m.Name)
+	}
+	var fields moduleConfigFields
+	if err := node.Decode(&fields); err != nil {
+		return err
+	}
+	*m = ModuleConfig(fields)
+	return nil
+}
+
+// MarshalYAML writes entries without options as plain names.
+func (m ModuleConfig) MarshalYAML() (interface{}, error) {
+	if m == (ModuleConfig{Name: m.Name}) {
+		return m.Name, nil
+	}
+	return moduleConfigFields(m), nil
+}
+
+// Modules returns module entries with no options for names.
+func Modules(names ...string) []ModuleConfig {
+	mods := make([]ModuleConfig, len(names))
+	for i, name := range names {
+		mods[i] = ModuleConfig{Name: name}
+	}
+	return mods
+}
+
+// ModuleNames returns the names of module entries.
+func ModuleNames(mods []ModuleConfig) []string {
+	names := make([]string, len(mods))
+	for i, mod := range mods {
+		names[i] = mod.Name
+	}
+	return names
+}
+
+// Module returns the configured entry for a module name, from the modules
+// list or a layout section, so lists that only name modules, like a theme's
+// sections, get the same options. Unconfigured names get no options.
+func (c *Config) Module(name string) ModuleConfig {
+	for _, mod := range c.Modules {
+		if mod.Name == name {
+			return mod
+		}
+	}
+	for _, section := range c.Layout.Sections {
+		for _, mod := range section.Modules {
+			if mod.Name == name {
+				return mod
+			}
+		}
+	}
+	return ModuleConfig{Name: name}
+}
+
+func validateModules(field string, mods []ModuleConfig) error {
+	for i, mod := range mods {
+		if mod.Name == "" {
+			return fmt.Errorf("%s[%d]: name is required", field, i)
+		}
+		switch {
+		case mod.Type != "" && mod.Type != ModuleTypeCustom:
+			return fmt.Errorf("%s[%d].type: invalid value %q (use custom or leave it out)", field, i, mod.Type)
+		case mod.Type == ModuleTypeCustom && mod.Template == "":
+			return fmt.Errorf("%s[%d] (%s): custom modules need a template", field, i, mod.Name)
+		case mod.Type != ModuleTypeCustom && mod.Template != "":
+			return fmt.Errorf("%s[%d] (%s): template needs type: custom", field, i, mod.Name)
+		}
+		if _, ok := ByteUnits[mod.Unit]; mod.Unit != "" && !ok {
+			return fmt.Errorf("%s[%d].unit: invalid value %q (use B, KiB, MiB, GiB, TiB, KB, MB, GB, or TB)", field, i, mod.Unit)
+		}
+		if err := mod.Bar.validate(); err != nil {
+			return fmt.Errorf("%s[%d].bar.%w", field, i, err)
+		}
+	}
+	return nil
+}
+
+func (b *BarConfig) validate() error {
+	if b == nil {
+		return nil
+	}
+	switch b.Glyphs {
+	case "", "blocks", "braille", "ascii":
+	default:
+		return fmt.Errorf("glyphs: invalid value %q (use blocks, braille, or ascii)", b.Glyphs)
+	}
+	if b.Width < 0 {
+		return fmt.Errorf("width: must not be negative")
+	}
+	for name, percent := range map[string]float64{"warning": b.Warning, "critical": b.Critical} {
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("%s: must be between 0 and 100", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+)
+
+// Animation renders a sequence of samples as an animated GIF or SVG, one
+// frame per sample, using the same themed render as PNG export.
+type Animation struct {
+	frames []*ImageExporter
+	delay  time.Duration
+}
+
+// NewAnimation creates an animation showing each sample for delay.
+func NewAnimation(samples []*collectors.SystemInfo, cfg *config.Config, delay time.Duration) (*Animation, error) {
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("animation needs at least one sample")
+	}
+	if delay <= 0 {
+		delay = time.Second
+	}
+
+	frames := make([]*ImageExporter, 0, len(samples))
+	for _, info := range samples {
+		exporter, err := NewImageExporter(info, cfg)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, exporter)
+	}
+	return &Animation{frames: frames, delay: delay}, nil
+}
+
+// ToGIF writes the animation as a looping GIF.
+func (a *Animation) ToGIF(outputPath string) error {
+	return writeFile(outputPath, a.WriteGIF)
+}
+
+// ToSVG writes the animation as a looping SVG.
+func (a *Animation) ToSVG(outputPath string) error {
+	return writeFile(outputPath, a.WriteSVG)
+}
+
+func writeFile(outputPath string, write func(io.Writer) error) error {
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err := write(w); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteGIF encodes the animation as a looping GIF. Frames are padded to the
+// largest frame and share one palette built from the most common colors, so
+// text stays crisp instead of dithered.
+func (a *Animation) WriteGIF(w io.Writer) error {
+	renderMu.Lock()
+	defer renderMu.Unlock()
+
+	images := make([]image.Image, 0, len(a.frames))
+	bounds := image.Rectangle{}
+	for _, frame := range a.frames {
+		dc, err := frame.drawPNG()
+		if err != nil {
+			return err
+		}
+		img := dc.Image()
+		images = append(images, img)
+		bounds = bounds.Union(img.Bounds())
+	}
+
+	background := parseHexColor(a.frames[0].theme.Colors.Background)
+	if a.frames[0].theme.Colors.Background == "" {
+		background = parseHexColor("#1e1e2e")
+	}
+	palette := buildPalette(images, background)
+
+	delay := int(math.Round(a.delay.Seconds() * 100))
+	if delay < 2 {
+		delay = 2
+	}
+
+	anim := &gif.GIF{LoopCount: 0}
+	for _, img := range images {
+		paletted := image.NewPaletted(bounds, palette)
+		draw.Draw(paletted, bounds, image.NewUniform(background), image.Point{}, draw.Src)
+		draw.Draw(paletted, img.Bounds(), img, img.Bounds().Min, draw.Src)
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, delay)
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// buildPalette returns up to 256 colors, most frequent first, always
+// including the background.
+func buildPalette(images []image.Image, background color.Color) color.Palette {
+	counts := map[color.RGBA]int{}
+	for _, img := range images {
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				r, g, bl, _ := img.At(x, y).RGBA()
+				counts[color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(bl >> 8), 255}]++
+			}
+		}
+	}
+
+	colors := make([]color.RGBA, 0, len(counts))
+	for c := range counts {
+		colors = append(colors, c)
+	}
+	key := func(c color.RGBA) uint32 {
+		return uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B)
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if counts[colors[i]] != counts[colors[j]] {
+			return counts[colors[i]] > counts[colors[j]]
+		}
+		return key(colors[i]) < key(colors[j])
+	})
+
+	palette := color.Palette{background}
+	for _, c := range colors {
+		if len(palette) == 256 {
+			break
+		}
+		if c != color.RGBAModel.Convert(background) {
+			palette = append(palette, c)
+		}
+	}
+	return palette
+}
+
+// WriteSVG encodes the animation as an SVG whose frames are shown in turn
+// with discrete SMIL animation. Text stays selectable and scales cleanly.
+func (a *Animation) WriteSVG(w io.Writer) error {
+	renderMu.Lock()
+	defer renderMu.Unlock()
+
+	first := a.frames[0]
+	fontSize := first.fontSize
+	cellW := fontSize * 0.6
+	cellH := math.Ceil(fontSize * pngLineHeight)
+
+	grids := make([][][]cell, 0, len(a.frames))
+	cols, rows := 0, 0
+	for _, frame := range a.frames {
+		lines := parseANSI(frame.renderTrueColor())
+		for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+			lines = lines[:len(lines)-1]
+		}
+		for _, line := range lines {
+			if width := lineWidth(line); width > cols {
+				cols = width
+			}
+		}
+		if len(lines) > rows {
+			rows = len(lines)
+		}
+		grids = append(grids, lines)
+	}
+
+	width := 2*pngPadding + float64(cols)*cellW
+	height := 2*pngPadding + float64(rows)*cellH
+	background := first.theme.Colors.Background
+	if background == "" {
+		background = "#1e1e2e"
+	}
+	foreground := first.theme.Colors.Value
+	if foreground == "" {
+		foreground = "#ffffff"
+	}
+	total := a.delay.Milliseconds() * int64(len(a.frames))
+
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">
+<rect width="100%%" height="100%%" fill="%s"/>
+<g font-family="'JetBrainsMono Nerd Font', 'Symbols Nerd Font', 'DejaVu Sans Mono', monospace" font-size="%g" fill="%s" xml:space="preserve">
+`, width, height, width, height, html.EscapeString(background), fontSize, html.EscapeString(foreground))
+
+	n := len(grids)
+	for i, lines := range grids {
+		display := "none"
+		if i == 0 {
+			display = "inline"
+		}
+		fmt.Fprintf(w, "<g display=\"%s\">\n", display)
+		if n > 1 {
+			start := float64(i) / float64(n)
+			end := float64(i+1) / float64(n)
+			values, keyTimes := "none;inline;none", fmt.Sprintf("0;%.4f;%.4f", start, end)
+			if i == 0 {
+				values, keyTimes = "inline;none", fmt.Sprintf("0;%.4f", end)
+			}
+			fmt.Fprintf(w, "<animate attributeName=\"display\" values=\"%s\" keyTimes=\"%s\" dur=\"%dms\" calcMode=\"discrete\" repeatCount=\"indefinite\"/>\n",
+				values, keyTimes, total)
+		}
+		for row, line := range lines {
+			writeSVGLine(w, line, pngPadding, pngPadding+float64(row)*cellH, cellW, cellH, fontSize)
+		}
+		io.WriteString(w, "</g>\n")
+	}
+
+	_, err := io.WriteString(w, "</g>\n</svg>\n")
+	return err
+}
+
+// writeSVGLine writes one grid row as background rects and text runs. Each
+// run is anchored at its column so alignment does not depend on the font.
+func writeSVGLine(w io.Writer, line []cell, x, y, cellW, cellH, fontSize float64) {
+	baseline := y + (cellH+fontSize*0.7)/2
+	col := 0
+	for i := 0; i < len(line); {
+		start := i
+		startCol := col
+		var text strings.Builder
+		for i < len(line) && sameStyle(line[start], line[i]) {
+			text.WriteString(line[i].text)
+			col += line[i].width
+			i++
+		}
+
+		c := line[start]
+		if c.bg != nil {
+			fmt.Fprintf(w, "<rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"%s\"/>\n",
+				x+float64(startCol)*cellW, y, float64(col-startCol)*cellW, cellH, hexColor(c.bg))
+		}
+		content := text.String()
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+
+		attrs := ""
+		if c.fg != nil {
+			attrs += fmt.Sprintf(" fill=\"%s\"", hexColor(c.fg))
+		}
+		if c.bold {
+			attrs += " font-weight=\"bold\""
+		}
+		fmt.Fprintf(w, "<text x=\"%.1f\" y=\"%.1f\"%s>%s</text>\n",
+			x+float64(startCol)*cellW, baseline, attrs, html.EscapeString(content))
+	}
+}
+
+func sameStyle(a, b cell) bool {
+	return a.bold == b.bold && colorEqual(a.fg, b.fg) && colorEqual(a.bg, b.bg)
+}
+
+func colorEqual(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return hexColor(a) == hexColor(b)
+}
+
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
@@ -0,0 +1,196 @@
+package export
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// cell is one terminal column of rendered output. Wide runes occupy a cell of
+// width 2; combining runes are appended to the preceding cell.
+type cell struct {
+	text  string
+	width int
+	fg    color.Color // nil means the default foreground
+	bg    color.Color // nil means no background
+	bold  bool
+}
+
+// sgrState is the current Select Graphic Rendition state.
+type sgrState struct {
+	fg   color.Color
+	bg   color.Color
+	bold bool
+}
+
+// parseANSI splits rendered terminal output into lines of cells, applying
+// SGR color and bold sequences. Other escape sequences are dropped.
+func parseANSI(s string) [][]cell {
+	var lines [][]cell
+	var line []cell
+	var state sgrState
+
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '[':
+			end := i + 2
+			for end < len(s) && (s[end] < 0x40 || s[end] > 0x7e) {
+				end++
+			}
+			if end < len(s) && s[end] == 'm' {
+				state.apply(s[i+2 : end])
+			}
+			i = end + 1
+			continue
+		case s[i] == '\x1b' && i+1 < len(s) && s[i+1] == ']':
+			// OSC (hyperlinks, titles) ends with BEL or ST.
+			end := i + 2
+			for end < len(s) && s[end] != '\a' && !(s[end] == '\x1b' && end+1 < len(s) && s[end+1] == '\\') {
+				end++
+			}
+			if end < len(s) && s[end] == '\x1b' {
+				end++
+			}
+			i = end + 1
+			continue
+		case s[i] == '\x1b':
+			i += 2
+			continue
+		case s[i] == '\n':
+			lines = append(lines, line)
+			line = nil
+			i++
+			continue
+		case s[i] == '\r':
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		width := runewidth.RuneWidth(r)
+		if width == 0 {
+			if len(line) > 0 && r >= ' ' {
+				line[len(line)-1].text += string(r)
+			}
+			continue
+		}
+		line = append(line, cell{
+			text:  string(r),
+			width: width,
+			fg:    state.fg,
+			bg:    state.bg,
+			bold:  state.bold,
+		})
+	}
+	return append(lines, line)
+}
+
+// lineWidth returns the number of terminal columns a line occupies.
+func lineWidth(line []cell) int {
+	width := 0
+	for _, c := range line {
+		width += c.width
+	}
+	return width
+}
+
+func (st *sgrState) apply(params string) {
+	if params == "" {
+		*st = sgrState{}
+		return
+	}
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			*st = sgrState{}
+		case code == 1:
+			st.bold = true
+		case code == 22:
+			st.bold = false
+		case code == 39:
+			st.fg = nil
+		case code == 49:
+			st.bg = nil
+		case code >= 30 && code <= 37:
+			st.fg = xtermColor(code - 30)
+		case code >= 90 && code <= 97:
+			st.fg = xtermColor(code - 90 + 8)
+		case code >= 40 && code <= 47:
+			st.bg = xtermColor(code - 40)
+		case code >= 100 && code <= 107:
+			st.bg = xtermColor(code - 100 + 8)
+		case code == 38 || code == 48:
+			c, consumed := extendedColor(codes[i+1:])
+			i += consumed
+			if c == nil {
+				continue
+			}
+			if code == 38 {
+				st.fg = c
+			} else {
+				st.bg = c
+			}
+		}
+	}
+}
+
+// extendedColor parses the arguments of a 38/48 sequence ("5;n" or
+// "2;r;g;b") and returns the color and the number of codes consumed.
+func extendedColor(codes []string) (color.Color, int) {
+	if len(codes) == 0 {
+		return nil, 0
+	}
+	values := make([]int, 0, 4)
+	for _, code := range codes {
+		n, err := strconv.Atoi(code)
+		if err != nil {
+			break
+		}
+		values = append(values, n)
+	}
+	switch {
+	case len(values) >= 2 && values[0] == 5:
+		return xtermColor(values[1]), 2
+	case len(values) >= 4 && values[0] == 2:
+		return color.RGBA{uint8(values[1]), uint8(values[2]), uint8(values[3]), 255}, 4
+	}
+	return nil, len(values)
+}
+
+var ansiBaseColors = [16]color.RGBA{
+	{0x00, 0x00, 0x00, 0xff}, {0xcd, 0x00, 0x00, 0xff}, {0x00, 0xcd, 0x00, 0xff}, {0xcd, 0xcd, 0x00, 0xff},
+	{0x00, 0x00, 0xee, 0xff}, {0xcd, 0x00, 0xcd, 0xff}, {0x00, 0xcd, 0xcd, 0xff}, {0xe5, 0xe5, 0xe5, 0xff},
+	{0x7f, 0x7f, 0x7f, 0xff}, {0xff, 0x00, 0x00, 0xff}, {0x00, 0xff, 0x00, 0xff}, {0xff, 0xff, 0x00, 0xff},
+	{0x5c, 0x5c, 0xff, 0xff}, {0xff, 0x00, 0xff, 0xff}, {0x00, 0xff, 0xff, 0xff}, {0xff, 0xff, 0xff, 0xff},
+}
+
+// xtermColor returns the RGB value of an xterm 256-color palette index.
+func xtermColor(n int) color.Color {
+	switch {
+	case n < 0 || n > 255:
+		return nil
+	case n < 16:
+		return ansiBaseColors[n]
+	case n < 232:
+		n -= 16
+		level := func(v int) uint8 {
+			if v == 0 {
+				return 0
+			}
+			return uint8(55 + v*40)
+		}
+		return color.RGBA{level(n / 36), level(n / 6 % 6), level(n % 6), 255}
+	default:
+		gray := uint8(8 + (n-232)*10)
+		return color.RGBA{gray, gray, gray, 255}
+	}
+}
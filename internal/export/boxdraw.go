@@ -0,0 +1,151 @@
+package export
+
+import (
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+// Line weights for box-drawing arms.
+const (
+	boxNone = iota
+	boxLight
+	boxHeavy
+	boxDouble
+)
+
+// boxGlyph describes a box-drawing character by the weight of its arms.
+type boxGlyph struct {
+	up, right, down, left int
+	rounded               bool
+}
+
+// boxGlyphs covers the borders lipgloss draws. They are painted as lines
+// rather than font glyphs so borders stay continuous at any line height.
+var boxGlyphs = map[rune]boxGlyph{
+	'─': {right: boxLight, left: boxLight},
+	'│': {up: boxLight, down: boxLight},
+	'┌': {right: boxLight, down: boxLight},
+	'┐': {left: boxLight, down: boxLight},
+	'└': {up: boxLight, right: boxLight},
+	'┘': {up: boxLight, left: boxLight},
+	'├': {up: boxLight, right: boxLight, down: boxLight},
+	'┤': {up: boxLight, down: boxLight, left: boxLight},
+	'┬': {right: boxLight, down: boxLight, left: boxLight},
+	'┴': {up: boxLight, right: boxLight, left: boxLight},
+	'┼': {up: boxLight, right: boxLight, down: boxLight, left: boxLight},
+	'╭': {right: boxLight, down: boxLight, rounded: true},
+	'╮': {left: boxLight, down: boxLight, rounded: true},
+	'╰': {up: boxLight, right: boxLight, rounded: true},
+	'╯': {up: boxLight, left: boxLight, rounded: true},
+	'━': {right: boxHeavy, left: boxHeavy},
+	'┃': {up: boxHeavy, down: boxHeavy},
+	'┏': {right: boxHeavy, down: boxHeavy},
+	'┓': {left: boxHeavy, down: boxHeavy},
+	'┗': {up: boxHeavy, right: boxHeavy},
+	'┛': {up: boxHeavy, left: boxHeavy},
+	'═': {right: boxDouble, left: boxDouble},
+	'║': {up: boxDouble, down: boxDouble},
+	'╔': {right: boxDouble, down: boxDouble},
+	'╗': {left: boxDouble, down: boxDouble},
+	'╚': {up: boxDouble, right: boxDouble},
+	'╝': {up: boxDouble, left: boxDouble},
+}
+
+// drawBoxRune paints r into the cell at (x, y) if it is a known box-drawing
+// character, using the current color. It reports whether r was drawn.
+func drawBoxRune(dc *gg.Context, r rune, x, y, w, h, scale float64) bool {
+	glyph, ok := boxGlyphs[r]
+	if !ok {
+		return false
+	}
+
+	light := math.Max(1, math.Round(scale))
+	// Odd widths are centered on a pixel, even widths on a pixel edge, so
+	// strokes stay crisp.
+	align := 0.0
+	if int(light)%2 == 1 {
+		align = 0.5
+	}
+	cx := math.Floor(x+w/2) + align
+	cy := math.Floor(y+h/2) + align
+	right, bottom := x+w, y+h
+
+	// Butt caps keep adjacent cells from overlapping where they meet.
+	dc.SetLineCapButt()
+	defer dc.SetLineCapRound()
+
+	weight := glyph.up | glyph.right | glyph.down | glyph.left
+	switch {
+	case weight == boxDouble:
+		d := light * 1.5
+		dc.SetLineWidth(light)
+		drawDoubleBox(dc, glyph, x, y, right, bottom, cx, cy, d)
+	case glyph.rounded:
+		dc.SetLineWidth(light)
+		var startX, startY, endX, endY float64
+		if glyph.left != boxNone {
+			startX, startY = x, cy
+		} else {
+			startX, startY = right, cy
+		}
+		if glyph.up != boxNone {
+			endX, endY = cx, y
+		} else {
+			endX, endY = cx, bottom
+		}
+		dc.MoveTo(startX, startY)
+		dc.QuadraticTo(cx, cy, endX, endY)
+	default:
+		lineWidth := light
+		if weight == boxHeavy {
+			lineWidth = light * 2
+		}
+		dc.SetLineWidth(lineWidth)
+		half := lineWidth / 2
+		if glyph.left != boxNone {
+			dc.DrawLine(x, cy, cx+half, cy)
+		}
+		if glyph.right != boxNone {
+			dc.DrawLine(cx-half, cy, right, cy)
+		}
+		if glyph.up != boxNone {
+			dc.DrawLine(cx, y, cx, cy+half)
+		}
+		if glyph.down != boxNone {
+			dc.DrawLine(cx, cy-half, cx, bottom)
+		}
+	}
+	dc.Stroke()
+	return true
+}
+
+// drawDoubleBox draws straight double lines and double corners as two
+// parallel strokes offset by d from the cell center.
+func drawDoubleBox(dc *gg.Context, glyph boxGlyph, x, y, right, bottom, cx, cy, d float64) {
+	horizontal := glyph.left != boxNone || glyph.right != boxNone
+	vertical := glyph.up != boxNone || glyph.down != boxNone
+
+	switch {
+	case horizontal && !vertical:
+		dc.DrawLine(x, cy-d, right, cy-d)
+		dc.DrawLine(x, cy+d, right, cy+d)
+	case vertical && !horizontal:
+		dc.DrawLine(cx-d, y, cx-d, bottom)
+		dc.DrawLine(cx+d, y, cx+d, bottom)
+	default:
+		sx, edgeX := 1.0, right
+		if glyph.left != boxNone {
+			sx, edgeX = -1, x
+		}
+		sy, edgeY := 1.0, bottom
+		if glyph.up != boxNone {
+			sy, edgeY = -1, y
+		}
+		for _, offset := range []float64{-d, d} {
+			cornerX, cornerY := cx+sx*offset, cy+sy*offset
+			dc.DrawLine(cornerX, cornerY, edgeX, cornerY)
+			dc.DrawLine(cornerX, cornerY, cornerX, edgeY)
+		}
+	}
+}
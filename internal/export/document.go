@@ -0,0 +1,229 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the version of the export document format. It is bumped
+// whenever a field is renamed, removed, or changes type; adding fields does
+// not bump it.
+const SchemaVersion = 1
+
+// Document is the versioned, stable form of the JSON and YAML exports. It is
+// deliberately decoupled from collectors.SystemInfo so internal changes cannot
+// alter the exported format by accident.
+type Document struct {
+	SchemaVersion int       `json:"schema_version" yaml:"schema_version" doc:"Export format version."`
+	Timestamp     time.Time `json:"timestamp" yaml:"timestamp" doc:"Collection time (RFC 3339, UTC)."`
+	Host          string    `json:"host" yaml:"host" doc:"Hostname of the collected system."`
+	Version       string    `json:"bubblefetch_version" yaml:"bubblefetch_version" doc:"bubblefetch version that produced the document."`
+	Collector     string    `json:"collector" yaml:"collector" doc:"Collector used: local, ssh, docker, podman, kubectl, machinectl, or chroot."`
+	System        System    `json:"system" yaml:"system" doc:"Collected system information for the configured built-in modules."`
+	// Modules is omitted when the document is not tied to a modules list.
+	Modules []ModuleOutput `json:"modules,omitempty" yaml:"modules,omitempty" doc:"Output of each configured module, in configured order."`
+}
+
+// System holds the exported system information. When built for a modules
+// list, only the fields backing those modules are serialized.
+type System struct {
+	OS          string       `json:"os" yaml:"os"`
+	Kernel      string       `json:"kernel" yaml:"kernel"`
+	Hostname    string       `json:"hostname" yaml:"hostname"`
+	Uptime      string       `json:"uptime" yaml:"uptime" doc:"Human-readable uptime, e.g. \"1d 2h 3m\"."`
+	CPU         string       `json:"cpu" yaml:"cpu"`
+	Memory      Usage        `json:"memory" yaml:"memory"`
+	Disk        Usage        `json:"disk" yaml:"disk" doc:"Root filesystem usage."`
+	Shell       string       `json:"shell" yaml:"shell"`
+	Terminal    string       `json:"terminal" yaml:"terminal"`
+	Resolution  string       `json:"resolution" yaml:"resolution"`
+	DE          string       `json:"de" yaml:"de" doc:"Desktop environment."`
+	WM          string       `json:"wm" yaml:"wm" doc:"Window manager."`
+	Theme       string       `json:"theme" yaml:"theme"`
+	Icons       string       `json:"icons" yaml:"icons"`
+	GPU         []string     `json:"gpu" yaml:"gpu"`
+	Network     []Interface  `json:"network" yaml:"network"`
+	Battery     Battery      `json:"battery" yaml:"battery"`
+	LocalIP     string       `json:"local_ip" yaml:"local_ip"`
+	PublicIP    string       `json:"public_ip" yaml:"public_ip"`
+	ModuleCosts []ModuleCost `json:"module_costs" yaml:"module_costs" doc:"Per-module collection cost."`
+
+	// selected holds the Go names of the fields to serialize; nil means all.
+	selected map[string]bool
+}
+
+// Usage is a used/total pair in bytes.
+type Usage struct {
+	UsedBytes  uint64 `json:"used_bytes" yaml:"used_bytes"`
+	TotalBytes uint64 `json:"total_bytes" yaml:"total_bytes"`
+}
+
+// Interface describes one network interface.
+type Interface struct {
+	Name string `json:"name" yaml:"name"`
+	IPv4 string `json:"ipv4" yaml:"ipv4"`
+	IPv6 string `json:"ipv6" yaml:"ipv6"`
+	MAC  string `json:"mac" yaml:"mac"`
+}
+
+// Battery describes the primary battery.
+type Battery struct {
+	Present       bool    `json:"present" yaml:"present"`
+	Percent       float64 `json:"percent" yaml:"percent"`
+	Charging      bool    `json:"charging" yaml:"charging"`
+	TimeRemaining string  `json:"time_remaining" yaml:"time_remaining"`
+}
+
+// ModuleCost is the time spent collecting one module.
+type ModuleCost struct {
+	Name       string  `json:"name" yaml:"name"`
+	DurationMS float64 `json:"duration_ms" yaml:"duration_ms"`
+}
+
+// Source describes where a document came from.
+type Source struct {
+	Version   string
+	Collector string
+}
+
+// NewDocument wraps info in a versioned export document stamped with the
+// current time. When mods is non-empty, the document is limited to those
+// modules and carries their rendered output, including plugins; otherwise it
+// contains every system field and no module list.
+func NewDocument(info *collectors.SystemInfo, source Source, mods []config.ModuleConfig) *Document {
+	system := NewSystem(info)
+	doc := &Document{
+		SchemaVersion: SchemaVersion,
+		Timestamp:     time.Now().UTC().Truncate(time.Second),
+		Host:          system.Hostname,
+		Version:       source.Version,
+		Collector:     source.Collector,
+	}
+	if len(mods) > 0 {
+		system.selected = selectedFields(config.ModuleNames(mods))
+		doc.Modules = CollectModules(info, mods)
+	}
+	doc.System = system
+	return doc
+}
+
+// NewSystem converts collected info to its exported form. Slices are always
+// non-nil so they serialize as empty arrays.
+func NewSystem(info *collectors.SystemInfo) System {
+	system := System{
+		GPU:         []string{},
+		Network:     []Interface{},
+		ModuleCosts: []ModuleCost{},
+	}
+	if info == nil {
+		return system
+	}
+
+	system.OS = info.OS
+	system.Kernel = info.Kernel
+	system.Hostname = info.Hostname
+	system.Uptime = info.Uptime
+	system.CPU = info.CPU
+	system.Memory = Usage{UsedBytes: info.Memory.Used, TotalBytes: info.Memory.Total}
+	system.Disk = Usage{UsedBytes: info.Disk.Used, TotalBytes: info.Disk.Total}
+	system.Shell = info.Shell
+	system.Terminal = info.Terminal
+	system.Resolution = info.Resolution
+	system.DE = info.DE
+	system.WM = info.WM
+	system.Theme = info.Theme
+	system.Icons = info.Icons
+	system.GPU = append(system.GPU, info.GPU...)
+	for _, iface := range info.Network {
+		system.Network = append(system.Network, Interface{
+			Name: iface.Interface,
+			IPv4: iface.IPv4,
+			IPv6: iface.IPv6,
+			MAC:  iface.MAC,
+		})
+	}
+	system.Battery = Battery{
+		Present:       info.Battery.Present,
+		Percent:       info.Battery.Percentage,
+		Charging:      info.Battery.IsCharging,
+		TimeRemaining: info.Battery.TimeRemain,
+	}
+	system.LocalIP = info.LocalIP
+	system.PublicIP = info.PublicIP
+	for _, cost := range info.ModuleCosts {
+		system.ModuleCosts = append(system.ModuleCosts, ModuleCost{
+			Name:       cost.Name,
+			DurationMS: cost.DurationMS,
+		})
+	}
+	return system
+}
+
+type systemField struct {
+	key   string
+	value interface{}
+}
+
+// fields returns the selected fields in declaration order.
+func (s System) fields() []systemField {
+	v := reflect.ValueOf(s)
+	t := v.Type()
+	fields := make([]systemField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if s.selected != nil && !s.selected[sf.Name] {
+			continue
+		}
+		fields = append(fields, systemField{key: fieldKey(sf), value: v.Field(i).Interface()})
+	}
+	return fields
+}
+
+// MarshalJSON writes only the selected fields, keeping declaration order.
+func (s System) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range s.fields() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML writes only the selected fields, keeping declaration order.
+func (s System) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, field := range s.fields() {
+		var value yaml.Node
+		if err := value.Encode(field.value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: field.key},
+			&value,
+		)
+	}
+	return node, nil
+}
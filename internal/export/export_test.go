@@ -0,0 +1,90 @@
+package export
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// testInfo is a fixed system with values that need quoting or escaping in
+// most formats.
+func testInfo() *collectors.SystemInfo {
+	return &collectors.SystemInfo{
+		OS:       `Arch Linux "rolling"`,
+		Kernel:   "6.8.1-arch1-1",
+		Hostname: "build-box",
+		Uptime:   "1d 2h 3m",
+		CPU:      "AMD Ryzen 7 5800X (16) @ 3.8GHz",
+		Memory:   collectors.MemoryInfo{Used: 8589934592, Total: 34359738368},
+		Disk:     collectors.DiskInfo{Used: 107374182400, Total: 536870912000},
+		Shell:    "zsh 5.9",
+		Terminal: "it's a \"terminal\"\nwith a newline",
+		WM:       "sway",
+		GPU:      []string{"AMD Radeon RX 6800", "Intel UHD Graphics 770"},
+		Network: []collectors.NetworkInfo{
+			{Interface: "eth0", IPv4: "192.168.1.10", IPv6: "fe80::1", MAC: "aa:bb:cc:dd:ee:ff"},
+		},
+		Battery: collectors.BatteryInfo{Present: true, Percentage: 87.5, TimeRemain: "3h 5m"},
+		LocalIP: "192.168.1.10",
+		ModuleCosts: []collectors.ModuleCost{
+			{Name: "os", DurationMS: 0.25},
+		},
+	}
+}
+
+// testDocument wraps testInfo in a document with a fixed timestamp.
+func testDocument() *Document {
+	doc := NewDocument(testInfo(), Source{Version: "1.2.3", Collector: "local"}, nil)
+	doc.Timestamp = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	return doc
+}
+
+// checkGolden compares got with testdata/name, rewriting it with -update.
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if !bytes.Equal([]byte(got), want) {
+		t.Errorf("%s mismatch\n--- got\n%s\n--- want\n%s", name, got, want)
+	}
+}
+
+func TestToJSONGolden(t *testing.T) {
+	got, err := ToJSON(testDocument(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "document.json", got+"\n")
+}
+
+func TestJSONSchemaMatchesDocs(t *testing.T) {
+	got, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join("..", "..", "docs", "schema", "export-v1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("--print-schema output differs from docs/schema/export-v1.json; regenerate it with bubblefetch --print-schema")
+	}
+}
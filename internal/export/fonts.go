@@ -0,0 +1,206 @@
+package export
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+)
+
+// fontSet holds the faces used for PNG export. Go Mono is embedded so output
+// does not depend on installed fonts; icons and other symbols fall back to a
+// configured font, an installed Nerd Font, the embedded Symbols Nerd Font,
+// then a common system font.
+type fontSet struct {
+	regular  []fontFace
+	bold     []fontFace
+	ascent   float64
+	height   float64
+	advanceM float64
+}
+
+type fontFace struct {
+	font *truetype.Font
+	face font.Face
+}
+
+// symbolsFont is the monospaced Nerd Fonts "Symbols Only" build: the icon
+// glyphs, one cell wide, without letters. It and its license are fetched
+// into fonts/ by make fonts; the build fails if either is missing.
+const symbolsFont = "fonts/SymbolsNerdFontMono-Regular.ttf"
+
+//go:embed fonts/SymbolsNerdFontMono-Regular.ttf fonts/LICENSE
+var embeddedFonts embed.FS
+
+var (
+	parsedFontsMu sync.Mutex
+	parsedFonts   = map[string]*truetype.Font{}
+)
+
+// parseFont parses and caches a TrueType font by key.
+func parseFont(key string, data func() ([]byte, error)) (*truetype.Font, error) {
+	parsedFontsMu.Lock()
+	defer parsedFontsMu.Unlock()
+
+	if f, ok := parsedFonts[key]; ok {
+		return f, nil
+	}
+	raw, err := data()
+	if err != nil {
+		return nil, err
+	}
+	f, err := truetype.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font %s: %w", key, err)
+	}
+	parsedFonts[key] = f
+	return f, nil
+}
+
+func embeddedFont(data []byte) func() ([]byte, error) {
+	return func() ([]byte, error) { return data, nil }
+}
+
+func fileFont(path string) func() ([]byte, error) {
+	return func() ([]byte, error) { return os.ReadFile(path) }
+}
+
+// loadFontSet builds faces at size. iconFont, when set, must load; discovered
+// fallbacks are skipped silently if they cannot be parsed.
+func loadFontSet(size float64, iconFont string) (*fontSet, error) {
+	regular, err := parseFont("gomono", embeddedFont(gomono.TTF))
+	if err != nil {
+		return nil, err
+	}
+	bold, err := parseFont("gomonobold", embeddedFont(gomonobold.TTF))
+	if err != nil {
+		return nil, err
+	}
+
+	var fallbacks []*truetype.Font
+	if iconFont != "" {
+		f, err := parseFont(iconFont, fileFont(iconFont))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load image font: %w", err)
+		}
+		fallbacks = append(fallbacks, f)
+	}
+	if path := installedNerdFont(); path != "" {
+		if f, err := parseFont(path, fileFont(path)); err == nil {
+			fallbacks = append(fallbacks, f)
+		}
+	}
+	symbols, err := parseFont(symbolsFont, func() ([]byte, error) { return embeddedFonts.ReadFile(symbolsFont) })
+	if err != nil {
+		return nil, err
+	}
+	fallbacks = append(fallbacks, symbols)
+	if path := findFont(); path != "" {
+		if f, err := parseFont(path, fileFont(path)); err == nil {
+			fallbacks = append(fallbacks, f)
+		}
+	}
+
+	newFace := func(f *truetype.Font) fontFace {
+		return fontFace{font: f, face: truetype.NewFace(f, &truetype.Options{
+			Size:    size,
+			Hinting: font.HintingFull,
+		})}
+	}
+
+	set := &fontSet{}
+	set.regular = append(set.regular, newFace(regular))
+	set.bold = append(set.bold, newFace(bold))
+	for _, f := range fallbacks {
+		face := newFace(f)
+		set.regular = append(set.regular, face)
+		set.bold = append(set.bold, face)
+	}
+
+	metrics := set.regular[0].face.Metrics()
+	set.ascent = float64(metrics.Ascent.Ceil())
+	set.height = float64(metrics.Height.Ceil())
+	if advance, ok := set.regular[0].face.GlyphAdvance('M'); ok {
+		set.advanceM = float64(advance.Ceil())
+	} else {
+		set.advanceM = size * 0.6
+	}
+	return set, nil
+}
+
+// cellWidth is the width of one terminal column.
+func (s *fontSet) cellWidth() float64 {
+	return s.advanceM
+}
+
+// faceFor returns the first face with a glyph for r, and its ascent.
+func (s *fontSet) faceFor(r rune, bold bool) (font.Face, float64) {
+	faces := s.regular
+	if bold {
+		faces = s.bold
+	}
+	for _, f := range faces {
+		if f.font.Index(r) != 0 {
+			return f.face, s.ascent
+		}
+	}
+	return faces[0].face, s.ascent
+}
+
+var (
+	nerdFontOnce sync.Once
+	nerdFontPath string
+)
+
+// installedNerdFont returns the Nerd Font found by findNerdFont, searching
+// the font directories only once per process.
+func installedNerdFont() string {
+	nerdFontOnce.Do(func() { nerdFontPath = findNerdFont() })
+	return nerdFontPath
+}
+
+// findNerdFont looks for an installed Nerd Font (TrueType only), preferring
+// the symbols-only build.
+func findNerdFont() string {
+	var dirs []string
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs,
+			filepath.Join(home, ".local", "share", "fonts"),
+			filepath.Join(home, ".fonts"),
+			filepath.Join(home, "Library", "Fonts"),
+		)
+	}
+	dirs = append(dirs, "/usr/share/fonts", "/usr/local/share/fonts", "/Library/Fonts")
+
+	var found string
+	for _, dir := range dirs {
+		filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			name := strings.ToLower(d.Name())
+			if !strings.HasSuffix(name, ".ttf") || !strings.Contains(strings.ReplaceAll(name, " ", ""), "nerdfont") {
+				return nil
+			}
+			if strings.Contains(name, "symbols") {
+				found = path
+				return filepath.SkipAll
+			}
+			if found == "" {
+				found = path
+			}
+			return nil
+		})
+		if found != "" {
+			return found
+		}
+	}
+	return ""
+}
@@ -0,0 +1,176 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// flatField is one leaf value of a document, keyed by its dotted path.
+type flatField struct {
+	key   string
+	value string
+}
+
+// documentNode encodes doc into an ordered YAML node tree, which the flat and
+// TOML encoders walk so every format shares the same fields and order.
+func documentNode(doc *Document) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(doc); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// flattenDocument returns the document as dotted key/value pairs. Module
+// output is keyed by module name ("modules.cpu.value") rather than position.
+func flattenDocument(doc *Document) ([]flatField, error) {
+	root, err := documentNode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []flatField
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+		if key == "modules" {
+			for _, mod := range value.Content {
+				name := mappingValue(mod, "name")
+				fields = flattenNode(fields, "modules."+name, withoutKey(mod, "name"))
+			}
+			continue
+		}
+		fields = flattenNode(fields, key, value)
+	}
+	return fields, nil
+}
+
+func flattenNode(fields []flatField, prefix string, node *yaml.Node) []flatField {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			fields = flattenNode(fields, prefix+"."+node.Content[i].Value, node.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			fields = flattenNode(fields, prefix+"."+strconv.Itoa(i), item)
+		}
+	case yaml.ScalarNode:
+		fields = append(fields, flatField{key: prefix, value: node.Value})
+	}
+	return fields
+}
+
+func mappingValue(node *yaml.Node, key string) string {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+func withoutKey(node *yaml.Node, key string) *yaml.Node {
+	trimmed := &yaml.Node{Kind: node.Kind}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != key {
+			trimmed.Content = append(trimmed.Content, node.Content[i], node.Content[i+1])
+		}
+	}
+	return trimmed
+}
+
+// ToCSV exports a document as "field,value" rows, one per leaf value.
+func ToCSV(doc *Document) (string, error) {
+	fields, err := flattenDocument(doc)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"field", "value"})
+	for _, field := range fields {
+		w.Write([]string{field.key, field.value})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ToEnv exports a document as shell-sourceable BUBBLEFETCH_* assignments.
+// System fields drop their "system." prefix, so system.os becomes
+// BUBBLEFETCH_OS.
+func ToEnv(doc *Document) (string, error) {
+	fields, err := flattenDocument(doc)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, field := range fields {
+		key := strings.TrimPrefix(field.key, "system.")
+		fmt.Fprintf(&b, "BUBBLEFETCH_%s=%s\n", envName(key), shellQuote(field.value))
+	}
+	return b.String(), nil
+}
+
+// envName upper-cases key and replaces anything outside [A-Z0-9_] with "_".
+func envName(key string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(key) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// shellQuote wraps value in single quotes, which POSIX shells never expand.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// ToMarkdown exports a document as a Markdown table of module output,
+// suitable for pasting into issues.
+func ToMarkdown(doc *Document) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n\n", markdownEscape(doc.Host))
+	b.WriteString("| Module | Value |\n")
+	b.WriteString("| --- | --- |\n")
+	for _, mod := range doc.Modules {
+		label := mod.Label
+		if label == "" {
+			label = mod.Name
+		}
+		values := make([]string, 0, len(mod.Lines)+1)
+		if mod.Value != "" {
+			values = append(values, markdownEscape(mod.Value))
+		}
+		for _, line := range mod.Lines {
+			values = append(values, markdownEscape(line))
+		}
+		fmt.Fprintf(&b, "| %s | %s |\n", markdownEscape(label), strings.Join(values, "<br>"))
+	}
+	fmt.Fprintf(&b, "\n<sub>bubblefetch %s · %s · %s</sub>\n",
+		markdownEscape(doc.Version),
+		markdownEscape(doc.Collector),
+		doc.Timestamp.Format("2006-01-02 15:04:05 MST"),
+	)
+	return b.String()
+}
+
+func markdownEscape(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "|", `\|`)
+	value = strings.ReplaceAll(value, "<", "&lt;")
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,36 @@
+package export
+
+import "testing"
+
+// testModulesDocument is testDocument with module output, as exported for a
+// modules list.
+func testModulesDocument() *Document {
+	doc := testDocument()
+	doc.Modules = []ModuleOutput{
+		{Name: "os", Source: SourceBuiltin, Label: "OS", Value: `Arch Linux "rolling"`},
+		{Name: "terminal", Source: SourceBuiltin, Label: "Terminal", Value: "it's a \"terminal\"\nwith a newline"},
+		{Name: "weather", Source: SourceExternal, Label: "Weather | Today", Value: "12°C <cloudy>", Lines: []string{"Wind: 5 km/h", `Path: C:\temp`}},
+	}
+	return doc
+}
+
+func TestFormatsGolden(t *testing.T) {
+	tests := []struct {
+		golden string
+		encode func(*Document) (string, error)
+	}{
+		{"document.toml", ToTOML},
+		{"document.csv", ToCSV},
+		{"document.env", ToEnv},
+		{"document.md", func(doc *Document) (string, error) { return ToMarkdown(doc), nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			got, err := tt.encode(testModulesDocument())
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, tt.golden, got)
+		})
+	}
+}
@@ -4,16 +4,45 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"io"
 	"os"
+
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
 )
 
+// htmlTemplate is a self-contained report page. Everything is inline so the
+// file works offline: theme colors are CSS variables switched by a
+// data-theme attribute, and the full export document is embedded as JSON.
 const htmlTemplate = `<!DOCTYPE html>
-<html lang="en">
+<html lang="en" data-theme="{{.ThemeName}}">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>bubblefetch - System Information</title>
+    <meta name="generator" content="bubblefetch{{with .Version}} v{{.}}{{end}}">
+    <title>{{with .Host}}{{.}} - {{end}}bubblefetch system report</title>
     <style>
+        :root {
+            --background: #1e1e2e;
+            --primary: #89b4fa;
+            --secondary: #cba6f7;
+            --accent: #f38ba8;
+            --label: #f9e2af;
+            --value: #a6e3a1;
+            --border: #585b70;
+        }
+        {{range .Themes}}
+        [data-theme="{{.Name}}"] {
+            {{with .Colors.Background}}--background: {{.}};{{end}}
+            {{with .Colors.Primary}}--primary: {{.}};{{end}}
+            {{with .Colors.Secondary}}--secondary: {{.}};{{end}}
+            {{with .Colors.Accent}}--accent: {{.}};{{end}}
+            {{with .Colors.Label}}--label: {{.}};{{end}}
+            {{with .Colors.Value}}--value: {{.}};{{end}}
+            {{with .Colors.Border}}--border: {{.}};{{end}}
+        }
+        {{end}}
+
         * {
             margin: 0;
             padding: 0;
@@ -21,30 +50,58 @@ const htmlTemplate = `<!DOCTYPE html>
         }
 
         body {
-            background: {{.Colors.Background}};
-            color: {{.Colors.Value}};
-            font-family: 'Courier New', 'Consolas', 'Monaco', monospace;
+            background: var(--background);
+            color: var(--value);
+            font-family: 'JetBrainsMono Nerd Font', 'Cascadia Code', 'Consolas', 'Monaco', 'Courier New', monospace;
             padding: 40px 20px;
             min-height: 100vh;
+        }
+
+        main {
+            max-width: 1000px;
+            margin: 0 auto;
+        }
+
+        .toolbar {
             display: flex;
+            justify-content: flex-end;
             align-items: center;
-            justify-content: center;
+            gap: 12px;
+            margin-bottom: 16px;
+            color: var(--secondary);
+            font-size: 12px;
+        }
+
+        select, button {
+            background: transparent;
+            color: var(--value);
+            border: 1px solid var(--border);
+            border-radius: 4px;
+            font: inherit;
+            padding: 2px 8px;
+            cursor: pointer;
+        }
+
+        select option {
+            background: var(--background);
+        }
+
+        button:hover, select:hover {
+            border-color: var(--primary);
         }
 
         .container {
             display: flex;
-            max-width: 1000px;
-            width: 100%;
             {{if ne .BorderStyle "none"}}
-            border: 2px solid {{.Colors.Border}};
+            border: 2px {{if eq .BorderStyle "double"}}double{{else}}solid{{end}} var(--border);
             {{end}}
-            border-radius: 10px;
+            border-radius: {{if eq .BorderStyle "rounded"}}10px{{else}}0{{end}};
             padding: 40px;
             gap: 40px;
         }
 
         .ascii {
-            color: {{.Colors.Primary}};
+            color: var(--primary);
             white-space: pre;
             flex: 0 0 auto;
             line-height: 1.4;
@@ -59,29 +116,92 @@ const htmlTemplate = `<!DOCTYPE html>
         .field {
             margin-bottom: 8px;
             display: flex;
+            align-items: baseline;
             gap: 4px;
         }
 
         .label {
-            color: {{.Colors.Label}};
+            color: var(--label);
             font-weight: bold;
             white-space: nowrap;
         }
 
         .separator {
-            color: {{.Colors.Label}};
+            color: var(--primary);
+            white-space: pre;
         }
 
         .value {
-            color: {{.Colors.Value}};
+            color: var(--value);
             word-break: break-word;
         }
 
+        .lines {
+            margin: -4px 0 8px 2ch;
+            color: var(--value);
+            white-space: pre-wrap;
+        }
+
+        .copy {
+            margin-left: auto;
+            font-size: 11px;
+            padding: 0 6px;
+            opacity: 0;
+        }
+
+        .field:hover .copy, .copy:focus, tr:hover .copy {
+            opacity: 1;
+        }
+
+        details {
+            margin-top: 20px;
+            border-top: 1px solid var(--border);
+            padding-top: 12px;
+        }
+
+        summary {
+            color: var(--secondary);
+            font-weight: bold;
+            cursor: pointer;
+            margin-bottom: 8px;
+        }
+
+        table {
+            width: 100%;
+            border-collapse: collapse;
+            font-size: 13px;
+        }
+
+        th {
+            color: var(--label);
+            text-align: left;
+            font-weight: bold;
+        }
+
+        th, td {
+            padding: 4px 8px 4px 0;
+            word-break: break-all;
+        }
+
+        .bar {
+            height: 8px;
+            border: 1px solid var(--border);
+            border-radius: 4px;
+            margin-top: 6px;
+            overflow: hidden;
+        }
+
+        .bar > span {
+            display: block;
+            height: 100%;
+            background: var(--accent);
+        }
+
         .footer {
             margin-top: 30px;
             padding-top: 20px;
-            border-top: 1px solid {{.Colors.Border}};
-            color: {{.Colors.Secondary}};
+            border-top: 1px solid var(--border);
+            color: var(--secondary);
             font-size: 12px;
             text-align: center;
         }
@@ -90,40 +210,225 @@ const htmlTemplate = `<!DOCTYPE html>
             .container {
                 flex-direction: column;
                 gap: 20px;
+                padding: 20px;
             }
 
             .ascii {
                 font-size: 10px;
             }
         }
+
+        @media print {
+            :root, [data-theme] {
+                --background: #ffffff;
+                --primary: #000000;
+                --secondary: #444444;
+                --accent: #888888;
+                --label: #000000;
+                --value: #000000;
+                --border: #999999;
+            }
+
+            body {
+                padding: 0;
+            }
+
+            .toolbar, .copy {
+                display: none;
+            }
+
+            .container {
+                border-width: 1px;
+                padding: 20px;
+            }
+
+            details, tr {
+                break-inside: avoid;
+            }
+        }
     </style>
 </head>
 <body>
+<main>
+    <div class="toolbar">
+        <label for="theme-select">Theme</label>
+        <select id="theme-select">
+            {{range .Themes}}<option value="{{.Name}}"{{if eq .Name $.ThemeName}} selected{{end}}>{{.Name}}</option>{{end}}
+        </select>
+        <button type="button" data-copy-target="bubblefetch-data">Copy JSON</button>
+    </div>
     <div class="container">
-        <div class="ascii">{{.ASCII}}</div>
+        {{if .ASCII}}<div class="ascii">{{.ASCII}}</div>{{end}}
         <div class="info">
-            {{range .Fields}}
+            {{range .Modules}}
             <div class="field">
-                <span class="label">{{.Label}}</span>
-                <span class="separator">{{$.Separator}}</span>
+                {{if .Label}}<span class="label">{{.Label}}</span><span class="separator">: </span>{{end}}
                 <span class="value">{{.Value}}</span>
+                <button type="button" class="copy" data-copy="{{.Value}}" title="Copy value">Copy</button>
             </div>
+            {{if .Lines}}<div class="lines">{{range .Lines}}{{.}}
+{{end}}</div>{{end}}
+            {{end}}
+
+            {{with .System.Network}}
+            <details open>
+                <summary>Network</summary>
+                <table>
+                    <tr><th>Interface</th><th>IPv4</th><th>IPv6</th><th>MAC</th><th></th></tr>
+                    {{range .}}
+                    <tr>
+                        <td>{{.Name}}</td>
+                        <td>{{.IPv4}}</td>
+                        <td>{{.IPv6}}</td>
+                        <td>{{.MAC}}</td>
+                        <td><button type="button" class="copy" data-copy="{{.Name}} {{.IPv4}} {{.IPv6}} {{.MAC}}" title="Copy row">Copy</button></td>
+                    </tr>
+                    {{end}}
+                </table>
+                {{if or $.System.LocalIP $.System.PublicIP}}
+                <table>
+                    {{with $.System.LocalIP}}<tr><th>Local IP</th><td>{{.}}</td><td><button type="button" class="copy" data-copy="{{.}}">Copy</button></td></tr>{{end}}
+                    {{with $.System.PublicIP}}<tr><th>Public IP</th><td>{{.}}</td><td><button type="button" class="copy" data-copy="{{.}}">Copy</button></td></tr>{{end}}
+                </table>
+                {{end}}
+            </details>
+            {{end}}
+
+            {{with .System.GPU}}
+            <details open>
+                <summary>GPU</summary>
+                <table>
+                    {{range .}}
+                    <tr><td>{{.}}</td><td><button type="button" class="copy" data-copy="{{.}}" title="Copy">Copy</button></td></tr>
+                    {{end}}
+                </table>
+            </details>
             {{end}}
+
+            {{if .Disk.Total}}
+            <details open>
+                <summary>Disk</summary>
+                <div class="field">
+                    <span class="label">/</span><span class="separator">: </span>
+                    <span class="value">{{.Disk.Used}} / {{.Disk.Total}} ({{.Disk.Percent}}%)</span>
+                </div>
+                <div class="bar"><span style="width: {{.Disk.Percent}}%"></span></div>
+            </details>
+            {{end}}
+
             <div class="footer">
-                Generated with bubblefetch v{{.Version}} | Theme: {{.ThemeName}}
+                Generated with bubblefetch{{with .Version}} v{{.}}{{end}}{{with .Collector}} | Collector: {{.}}{{end}} | {{.Timestamp}}
             </div>
         </div>
     </div>
+</main>
+<script type="application/json" id="bubblefetch-data">{{.Document}}</script>
+<script>
+(function () {
+    var root = document.documentElement;
+    var select = document.getElementById('theme-select');
+    select.addEventListener('change', function () {
+        root.setAttribute('data-theme', select.value);
+    });
+
+    function fallbackCopy(text) {
+        var area = document.createElement('textarea');
+        area.value = text;
+        area.style.position = 'fixed';
+        area.style.opacity = '0';
+        document.body.appendChild(area);
+        area.select();
+        try { document.execCommand('copy'); } catch (e) {}
+        document.body.removeChild(area);
+    }
+
+    function copy(text, button) {
+        var done = function () {
+            var label = button.textContent;
+            button.textContent = 'Copied';
+            setTimeout(function () { button.textContent = label; }, 1200);
+        };
+        if (navigator.clipboard && window.isSecureContext) {
+            navigator.clipboard.writeText(text).then(done, function () { fallbackCopy(text); done(); });
+            return;
+        }
+        fallbackCopy(text);
+        done();
+    }
+
+    document.addEventListener('click', function (event) {
+        var button = event.target.closest('button');
+        if (!button) { return; }
+        if (button.hasAttribute('data-copy')) {
+            copy(button.getAttribute('data-copy'), button);
+        } else if (button.hasAttribute('data-copy-target')) {
+            var data = document.getElementById(button.getAttribute('data-copy-target')).textContent;
+            copy(JSON.stringify(JSON.parse(data), null, 2), button);
+        }
+    });
+
+    // Print every section, then restore what the reader had collapsed.
+    var collapsed = [];
+    window.addEventListener('beforeprint', function () {
+        document.querySelectorAll('details:not([open])').forEach(function (d) {
+            collapsed.push(d);
+            d.open = true;
+        });
+    });
+    window.addEventListener('afterprint', function () {
+        collapsed.forEach(function (d) { d.open = false; });
+        collapsed = [];
+    });
+})();
+</script>
 </body>
 </html>`
 
-type htmlField struct {
-	Label string
-	Value string
+type htmlTheme struct {
+	Name   string
+	Colors theme.Colors
+}
+
+type htmlDisk struct {
+	Used    string
+	Total   string
+	Percent int
+}
+
+type htmlPage struct {
+	Host        string
+	Version     string
+	Collector   string
+	Timestamp   string
+	ThemeName   string
+	Themes      []htmlTheme
+	BorderStyle string
+	ASCII       string
+	Modules     []ModuleOutput
+	System      System
+	Disk        htmlDisk
+	Document    *Document
 }
 
 // ToHTML exports system info as an HTML file
 func (e *ImageExporter) ToHTML(outputPath string) error {
+	var buf bytes.Buffer
+	if err := e.WriteHTML(&buf); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write HTML file: %w", err)
+	}
+
+	return nil
+}
+
+// WriteHTML writes the HTML page to w.
+func (e *ImageExporter) WriteHTML(w io.Writer) error {
+	renderMu.Lock()
+	defer renderMu.Unlock()
+
 	tmpl, err := template.New("html").Parse(htmlTemplate)
 	if err != nil {
 		return fmt.Errorf("failed to parse HTML template: %w", err)
@@ -131,53 +436,63 @@ func (e *ImageExporter) ToHTML(outputPath string) error {
 
 	data := e.prepareHTMLData()
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	if err := tmpl.Execute(w, data); err != nil {
 		return fmt.Errorf("failed to execute HTML template: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
-		return fmt.Errorf("failed to write HTML file: %w", err)
-	}
-
 	return nil
 }
 
-func (e *ImageExporter) prepareHTMLData() map[string]interface{} {
-	ascii := e.theme.ASCII
-
-	// Get module data
-	fields := []htmlField{}
-	mods := e.getModules()
-	styles := e.theme.GetStyles()
+func (e *ImageExporter) prepareHTMLData() htmlPage {
+	// The embedded document carries every field, not just the configured
+	// modules, so the report is complete.
+	doc := NewDocument(e.info, e.source, nil)
+	doc.Modules = CollectModules(e.info, e.config.Modules)
 
-	for _, mod := range mods {
-		rendered := mod.Render(e.info, styles)
-		if rendered == "" {
+	themes := []htmlTheme{}
+	current := "default"
+	for _, name := range theme.List() {
+		thm, err := theme.Load(name)
+		if err != nil {
 			continue
 		}
-
-		label, value, ok := splitRendered(rendered, e.theme.Layout.Separator)
-		if !ok {
-			continue
+		themes = append(themes, htmlTheme{Name: name, Colors: thm.Colors})
+		if name == e.config.Theme {
+			current = name
 		}
+	}
+	// Imported color schemes are not in the theme list; offer the one in use.
+	if theme.IsImport(e.config.Theme) {
+		themes = append(themes, htmlTheme{Name: e.config.Theme, Colors: e.theme.Colors})
+		current = e.config.Theme
+	}
 
-		label = sanitizeText(label)
-		value = sanitizeText(value)
+	ascii := ""
+	if e.theme.Layout.ShowASCII {
+		ascii = theme.StripASCIIMarkup(e.theme.ASCII)
+	}
 
-		fields = append(fields, htmlField{
-			Label: label,
-			Value: value,
-		})
+	var disk htmlDisk
+	if total := doc.System.Disk.TotalBytes; total > 0 {
+		disk = htmlDisk{
+			Used:    sdk.FormatBytes(doc.System.Disk.UsedBytes),
+			Total:   sdk.FormatBytes(total),
+			Percent: int(doc.System.Disk.UsedBytes * 100 / total),
+		}
 	}
 
-	return map[string]interface{}{
-		"Colors":     e.theme.Colors,
-		"BorderStyle": e.theme.Layout.BorderStyle,
-		"ASCII":      ascii,
-		"Fields":     fields,
-		"Separator":  e.theme.Layout.Separator,
-		"Version":    "0.3.0",
-		"ThemeName":  e.config.Theme,
+	return htmlPage{
+		Host:        doc.Host,
+		Version:     e.source.Version,
+		Collector:   e.source.Collector,
+		Timestamp:   doc.Timestamp.Format("2006-01-02 15:04:05 MST"),
+		ThemeName:   current,
+		Themes:      themes,
+		BorderStyle: e.theme.Layout.BorderStyle,
+		ASCII:       ascii,
+		Modules:     doc.Modules,
+		System:      doc.System,
+		Disk:        disk,
+		Document:    doc,
 	}
 }
@@ -3,10 +3,10 @@ package export
 import (
 	"fmt"
 	"image/color"
+	"io"
 	"os"
 	"strings"
 
-	"github.com/fogleman/gg"
 	"github.com/howieduhzit/bubblefetch/internal/collectors"
 	"github.com/howieduhzit/bubblefetch/internal/config"
 	"github.com/howieduhzit/bubblefetch/internal/ui/modules"
@@ -15,13 +15,13 @@ import (
 
 // ImageExporter handles exporting system info as images
 type ImageExporter struct {
-	info      *collectors.SystemInfo
-	theme     *theme.Theme
-	config    *config.Config
-	width     int
-	height    int
-	fontSize  float64
-	lineSpace float64
+	info     *collectors.SystemInfo
+	theme    *theme.Theme
+	config   *config.Config
+	fontSize float64
+	scale    float64
+	iconFont string
+	source   Source
 }
 
 // NewImageExporter creates a new image exporter
@@ -35,95 +35,60 @@ func NewImageExporter(info *collectors.SystemInfo, cfg *config.Config) (*ImageEx
 		thm.ApplyAutoASCII(info.OS)
 	}
 
+	scale := cfg.Image.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	if scale > 8 {
+		return nil, fmt.Errorf("image scale %g is too large (max 8)", scale)
+	}
+
 	return &ImageExporter{
-		info:      info,
-		theme:     thm,
-		config:    cfg,
-		width:     800,
-		height:    600,
-		fontSize:  14,
-		lineSpace: 20,
+		info:     info,
+		theme:    thm,
+		config:   cfg,
+		fontSize: 14,
+		scale:    scale,
+		iconFont: cfg.Image.Font,
 	}, nil
 }
 
+// SetSource records the bubblefetch version and collector shown in exports
+// that embed the full document, such as HTML.
+func (e *ImageExporter) SetSource(source Source) {
+	e.source = source
+}
+
 // ToPNG exports system info as a PNG image
 func (e *ImageExporter) ToPNG(outputPath string) error {
-	dc := gg.NewContext(e.width, e.height)
-
-	// Background
-	bg := parseHexColor(e.theme.Colors.Background)
-	dc.SetColor(bg)
-	dc.Clear()
+	renderMu.Lock()
+	defer renderMu.Unlock()
 
-	// Load font - try multiple fallbacks
-	fontPath := findFont()
-	if err := dc.LoadFontFace(fontPath, e.fontSize); err != nil {
-		return fmt.Errorf("failed to load font: %w", err)
-	}
-
-	// Get ASCII art
-	ascii := e.theme.ASCII
-	asciiLines := strings.Split(ascii, "\n")
-
-	// Draw ASCII art (left side)
-	dc.SetColor(parseHexColor(e.theme.Colors.Primary))
-	x := 40.0
-	y := 80.0
-	for _, line := range asciiLines {
-		dc.DrawString(line, x, y)
-		y += e.lineSpace
+	dc, err := e.drawPNG()
+	if err != nil {
+		return err
 	}
+	return dc.SavePNG(outputPath)
+}
 
-	// Draw system info (right side)
-	infoX := 350.0
-	infoY := 80.0
-
-	// Get modules and render them
-	mods := e.getModules()
-	styles := e.theme.GetStyles()
-
-	for _, mod := range mods {
-		rendered := mod.Render(e.info, styles)
-		if rendered == "" {
-			continue
-		}
-
-		label, value, ok := splitRendered(rendered, e.theme.Layout.Separator)
-		if !ok {
-			continue
-		}
-
-		// Draw label
-		dc.SetColor(parseHexColor(e.theme.Colors.Label))
-		dc.DrawString(label+e.theme.Layout.Separator, infoX, infoY)
-
-		// Draw value
-		labelWidth, _ := dc.MeasureString(label + e.theme.Layout.Separator)
-		dc.SetColor(parseHexColor(e.theme.Colors.Value))
-		dc.DrawString(value, infoX+labelWidth, infoY)
-
-		infoY += e.lineSpace + 5
-	}
+// WritePNG encodes the PNG image to w.
+func (e *ImageExporter) WritePNG(w io.Writer) error {
+	renderMu.Lock()
+	defer renderMu.Unlock()
 
-	// Draw border if theme has one
-	if e.theme.Layout.BorderStyle != "none" {
-		dc.SetColor(parseHexColor(e.theme.Colors.Border))
-		dc.SetLineWidth(2)
-		margin := 20.0
-		dc.DrawRectangle(margin, margin, float64(e.width)-2*margin, float64(e.height)-2*margin)
-		dc.Stroke()
+	dc, err := e.drawPNG()
+	if err != nil {
+		return err
 	}
-
-	// Save PNG
-	return dc.SavePNG(outputPath)
+	return dc.EncodePNG(w)
 }
 
 // getModules returns the configured modules
 func (e *ImageExporter) getModules() []modules.Module {
 	mods := make([]modules.Module, 0, len(e.config.Modules))
 
-	for _, name := range e.config.Modules {
-		mod := modules.Factory(name)
+	for _, cfg := range e.config.Modules {
+		mod := modules.Factory(cfg)
 		if mod != nil {
 			mods = append(mods, mod)
 		}
@@ -142,7 +107,7 @@ func parseHexColor(hex string) color.Color {
 	return color.RGBA{r, g, b, 255}
 }
 
-// findFont tries to find a suitable monospace font
+// findFont returns the first common system monospace font that exists, or ""
 func findFont() string {
 	fonts := []string{
 		"/usr/share/fonts/truetype/dejavu/DejaVuSansMono.ttf",
@@ -160,15 +125,15 @@ func findFont() string {
 		}
 	}
 
-	// Default fallback - let gg handle it
-	return "/usr/share/fonts/truetype/dejavu/DejaVuSansMono.ttf"
+	return ""
 }
 
 // stripANSI removes ANSI escape codes from a string
 func stripANSI(s string) string {
 	// Simple ANSI stripping - remove escape sequences
 	// This handles the lipgloss styled output
-	result := ""
+	var result strings.Builder
+	result.Grow(len(s))
 	inEscape := false
 
 	for i := 0; i < len(s); i++ {
@@ -182,8 +147,8 @@ func stripANSI(s string) string {
 			}
 			continue
 		}
-		result += string(s[i])
+		result.WriteByte(s[i])
 	}
 
-	return result
+	return result.String()
 }
@@ -0,0 +1,136 @@
+package export
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"github.com/howieduhzit/bubblefetch/internal/ui/modules"
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+)
+
+// Module output sources.
+const (
+	SourceBuiltin  = "builtin"
+	SourcePlugin   = "plugin"
+	SourceExternal = "external"
+	SourceRPC      = "rpc"
+)
+
+// ModuleOutput is the exported output of one configured module.
+type ModuleOutput struct {
+	Name   string   `json:"name" yaml:"name" doc:"Module name from the modules list."`
+	Source string   `json:"source" yaml:"source" doc:"builtin, plugin, external, or rpc."`
+	Label  string   `json:"label,omitempty" yaml:"label,omitempty"`
+	Value  string   `json:"value,omitempty" yaml:"value,omitempty"`
+	Lines  []string `json:"lines,omitempty" yaml:"lines,omitempty" doc:"Additional output lines."`
+}
+
+// moduleFields maps module names to the System fields they display.
+var moduleFields = map[string][]string{
+	"os":       {"OS"},
+	"kernel":   {"Kernel"},
+	"hostname": {"Hostname"},
+	"uptime":   {"Uptime"},
+	"cpu":      {"CPU"},
+	"gpu":      {"GPU"},
+	"memory":   {"Memory"},
+	"disk":     {"Disk"},
+	"shell":    {"Shell"},
+	"terminal": {"Terminal"},
+	"de":       {"DE"},
+	"wm":       {"WM"},
+	"network":  {"Network"},
+	"localip":  {"LocalIP"},
+	"publicip": {"PublicIP"},
+	"battery":  {"Battery"},
+	"costs":    {"ModuleCosts"},
+}
+
+// fieldKey returns the JSON name of a struct field.
+func fieldKey(sf reflect.StructField) string {
+	if tag := sf.Tag.Get("json"); tag != "" {
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return sf.Name
+}
+
+// CollectModules renders each module in order and returns its output.
+// External modules and RPC plugins report their structured output directly; other modules
+// are rendered as plain text and split into label and value. Unknown modules
+// and modules with no output are skipped.
+func CollectModules(info *collectors.SystemInfo, mods []config.ModuleConfig) []ModuleOutput {
+	outputs := make([]ModuleOutput, 0, len(mods))
+	styles := theme.PlainStyles()
+
+	for _, cfg := range mods {
+		mod := modules.Factory(cfg)
+		if mod == nil {
+			continue
+		}
+
+		output := ModuleOutput{Name: cfg.Name, Source: SourceBuiltin}
+		if modules.IsPlugin(cfg.Name) {
+			output.Source = SourcePlugin
+		}
+
+		if dataMod, ok := mod.(modules.DataModule); ok {
+			data, ok := dataMod.Data(info)
+			if !ok {
+				continue
+			}
+			output.Source = SourceExternal
+			if data.Source != "" {
+				output.Source = data.Source
+			}
+			output.Label = data.Label
+			output.Value = data.Value
+			output.Lines = data.Lines
+			outputs = append(outputs, output)
+			continue
+		}
+
+		rendered := strings.TrimSpace(stripANSI(mod.Render(info, styles)))
+		if rendered == "" {
+			continue
+		}
+		lines := strings.Split(rendered, "\n")
+		if label, value, ok := splitRendered(lines[0], ": "); ok {
+			output.Label = trimIcon(label)
+			output.Value = value
+		} else {
+			output.Value = strings.TrimSpace(lines[0])
+		}
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				output.Lines = append(output.Lines, line)
+			}
+		}
+		outputs = append(outputs, output)
+	}
+
+	return outputs
+}
+
+// selectedFields returns the Go names of the System fields backing names.
+func selectedFields(names []string) map[string]bool {
+	selected := make(map[string]bool)
+	for _, name := range names {
+		for _, field := range moduleFields[strings.ToLower(strings.TrimSpace(name))] {
+			selected[field] = true
+		}
+	}
+	return selected
+}
+
+// trimIcon drops a leading icon, a Nerd Font glyph or a symbol like an
+// emoji, from a rendered label.
+func trimIcon(label string) string {
+	return strings.TrimSpace(strings.TrimLeftFunc(label, func(r rune) bool {
+		return unicode.In(r, unicode.Co, unicode.So) || unicode.IsSpace(r)
+	}))
+}
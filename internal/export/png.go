@@ -0,0 +1,105 @@
+package export
+
+import (
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fogleman/gg"
+	"github.com/howieduhzit/bubblefetch/internal/ui"
+	"github.com/muesli/termenv"
+)
+
+const (
+	pngPadding    = 24.0
+	pngLineHeight = 1.25
+)
+
+// renderMu serializes image exports. PNG and animated exports temporarily
+// switch the global lipgloss color profile, which every other export reads
+// while rendering, so each Write and To method holds it for its whole render.
+var renderMu sync.Mutex
+
+// renderTrueColor renders the fetch output exactly as the terminal would,
+// with 24-bit colors so theme hex values survive unchanged. It is fitted to
+// layout.width, if set, rather than to the terminal. Callers hold renderMu.
+func (e *ImageExporter) renderTrueColor() string {
+	previous := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(previous)
+
+	return ui.RenderExport(e.config, e.info)
+}
+
+// drawPNG paints the terminal render onto a canvas sized to fit it. Each
+// cell is drawn on a fixed grid like a terminal, so alignment and per-span
+// colors match the TUI.
+func (e *ImageExporter) drawPNG() (*gg.Context, error) {
+	lines := parseANSI(e.renderTrueColor())
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	scale := e.scale
+	fonts, err := loadFontSet(e.fontSize*scale, e.iconFont)
+	if err != nil {
+		return nil, err
+	}
+
+	cellW := fonts.cellWidth()
+	cellH := math.Ceil(e.fontSize * scale * pngLineHeight)
+	padding := math.Round(pngPadding * scale)
+
+	cols := 0
+	for _, line := range lines {
+		if w := lineWidth(line); w > cols {
+			cols = w
+		}
+	}
+	width := int(math.Ceil(2*padding + float64(cols)*cellW))
+	height := int(math.Ceil(2*padding + float64(len(lines))*cellH))
+
+	dc := gg.NewContext(width, height)
+	background := e.theme.Colors.Background
+	if background == "" {
+		background = "#1e1e2e"
+	}
+	dc.SetColor(parseHexColor(background))
+	dc.Clear()
+
+	var defaultFG color.Color = parseHexColor(e.theme.Colors.Value)
+	if e.theme.Colors.Value == "" {
+		defaultFG = color.White
+	}
+
+	for row, line := range lines {
+		y := padding + float64(row)*cellH
+		x := padding
+		for _, c := range line {
+			w := float64(c.width) * cellW
+			if c.bg != nil {
+				dc.SetColor(c.bg)
+				dc.DrawRectangle(x, y, w, cellH)
+				dc.Fill()
+			}
+
+			fg := c.fg
+			if fg == nil {
+				fg = defaultFG
+			}
+			dc.SetColor(fg)
+
+			r := []rune(c.text)[0]
+			if !drawBoxRune(dc, r, x, y, cellW, cellH, scale) && c.text != " " {
+				face, ascent := fonts.faceFor(r, c.bold)
+				dc.SetFontFace(face)
+				baseline := y + (cellH-fonts.height)/2 + ascent
+				dc.DrawString(c.text, x, baseline)
+			}
+			x += w
+		}
+	}
+
+	return dc, nil
+}
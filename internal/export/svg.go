@@ -3,9 +3,13 @@ package export
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"text/template"
+	"unicode/utf8"
+
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
 )
 
 const svgTemplate = `<?xml version="1.0" encoding="UTF-8"?>
@@ -40,6 +44,23 @@ const svgTemplate = `<?xml version="1.0" encoding="UTF-8"?>
 
 // ToSVG exports system info as an SVG image
 func (e *ImageExporter) ToSVG(outputPath string) error {
+	var buf bytes.Buffer
+	if err := e.WriteSVG(&buf); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write SVG file: %w", err)
+	}
+
+	return nil
+}
+
+// WriteSVG writes the SVG image to w.
+func (e *ImageExporter) WriteSVG(w io.Writer) error {
+	renderMu.Lock()
+	defer renderMu.Unlock()
+
 	tmpl, err := template.New("svg").Funcs(template.FuncMap{
 		"add": func(a, b int) int { return a + b },
 		"sub": func(a, b int) int { return a - b },
@@ -58,15 +79,10 @@ func (e *ImageExporter) ToSVG(outputPath string) error {
 
 	data := e.prepareSVGData()
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	if err := tmpl.Execute(w, data); err != nil {
 		return fmt.Errorf("failed to execute SVG template: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
-		return fmt.Errorf("failed to write SVG file: %w", err)
-	}
-
 	return nil
 }
 
@@ -77,7 +93,7 @@ type svgField struct {
 }
 
 func (e *ImageExporter) prepareSVGData() map[string]interface{} {
-	ascii := e.theme.ASCII
+	ascii := theme.StripASCIIMarkup(e.theme.ASCII)
 	rawAsciiLines := strings.Split(ascii, "\n")
 	asciiLines := make([]string, 0, len(rawAsciiLines))
 	for _, line := range rawAsciiLines {
@@ -104,7 +120,7 @@ func (e *ImageExporter) prepareSVGData() map[string]interface{} {
 		value = sanitizeText(value)
 
 		// Estimate label width for SVG positioning (rough approximation)
-		labelWidth := len(label+e.theme.Layout.Separator) * 8
+		labelWidth := svgTextWidth(label + e.theme.Layout.Separator)
 
 		fields = append(fields, svgField{
 			Label:      label,
@@ -113,9 +129,20 @@ func (e *ImageExporter) prepareSVGData() map[string]interface{} {
 		})
 	}
 
+	// Size the image to its content: ASCII art from x=40, fields from x=350,
+	// rows from y=80, and a 40px margin around everything.
+	width := 0
+	for _, line := range asciiLines {
+		width = max(width, 40+svgTextWidth(line))
+	}
+	for _, field := range fields {
+		width = max(width, 350+field.LabelWidth+svgTextWidth(field.Value))
+	}
+	height := 80 + max(len(asciiLines)*20, len(fields)*25)
+
 	return map[string]interface{}{
-		"Width":       e.width,
-		"Height":      e.height,
+		"Width":       width + 40,
+		"Height":      height + 40,
 		"Colors":      e.theme.Colors,
 		"BorderStyle": e.theme.Layout.BorderStyle,
 		"ASCIILines":  asciiLines,
@@ -123,3 +150,8 @@ func (e *ImageExporter) prepareSVGData() map[string]interface{} {
 		"Separator":   e.theme.Layout.Separator,
 	}
 }
+
+// svgTextWidth estimates the width of 14px monospace text.
+func svgTextWidth(s string) int {
+	return utf8.RuneCountInString(s) * 8
+}
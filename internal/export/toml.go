@@ -0,0 +1,140 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ToTOML exports a document as TOML. Nested objects become tables and lists
+// of objects (network, modules) become arrays of tables.
+func ToTOML(doc *Document) (string, error) {
+	root, err := documentNode(doc)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	writeTOMLTable(&b, nil, root)
+	return b.String(), nil
+}
+
+// writeTOMLTable writes the keys of a mapping node. TOML requires plain keys
+// before any sub-table, so tables are written after the scalar values.
+func writeTOMLTable(b *strings.Builder, path []string, node *yaml.Node) {
+	type entry struct {
+		key   string
+		value *yaml.Node
+	}
+	var tables, arrays []entry
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case value.Kind == yaml.MappingNode:
+			tables = append(tables, entry{key, value})
+		case isTableArray(value):
+			arrays = append(arrays, entry{key, value})
+		default:
+			if literal, ok := tomlValue(value); ok {
+				fmt.Fprintf(b, "%s = %s\n", tomlKey(key), literal)
+			}
+		}
+	}
+
+	for _, table := range tables {
+		sub := append(append([]string{}, path...), table.key)
+		fmt.Fprintf(b, "\n[%s]\n", tomlPath(sub))
+		writeTOMLTable(b, sub, table.value)
+	}
+	for _, array := range arrays {
+		sub := append(append([]string{}, path...), array.key)
+		for _, item := range array.value.Content {
+			fmt.Fprintf(b, "\n[[%s]]\n", tomlPath(sub))
+			writeTOMLTable(b, sub, item)
+		}
+	}
+}
+
+func isTableArray(node *yaml.Node) bool {
+	return node.Kind == yaml.SequenceNode && len(node.Content) > 0 && node.Content[0].Kind == yaml.MappingNode
+}
+
+// tomlValue formats a scalar or a list of scalars as a TOML literal.
+func tomlValue(node *yaml.Node) (string, bool) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if literal, ok := tomlValue(item); ok {
+				items = append(items, literal)
+			}
+		}
+		return "[" + strings.Join(items, ", ") + "]", true
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!null":
+			return "", false
+		case "!!int", "!!bool", "!!timestamp":
+			return node.Value, true
+		case "!!float":
+			if !strings.ContainsAny(node.Value, ".eE") {
+				return node.Value + ".0", true
+			}
+			return node.Value, true
+		default:
+			return tomlString(node.Value), true
+		}
+	}
+	return "", false
+}
+
+func tomlPath(path []string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = tomlKey(key)
+	}
+	return strings.Join(keys, ".")
+}
+
+// tomlKey returns key bare when it only has letters, digits, "-" and "_".
+func tomlKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+	for _, r := range key {
+		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_') {
+			return tomlString(key)
+		}
+	}
+	return key
+}
+
+// tomlString quotes value as a TOML basic string.
+func tomlString(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -1,12 +1,14 @@
 package plugins
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,12 +16,24 @@ import (
 	"github.com/howieduhzit/bubblefetch/internal/collectors"
 	"github.com/howieduhzit/bubblefetch/internal/ui/modules"
 	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk/rpcplugin"
 )
 
 type externalModule struct {
 	name    string
 	path    string
 	timeout time.Duration
+	host    *Host
+}
+
+// externalInput is the JSON written to an external module's stdin.
+type externalInput struct {
+	Module        string           `json:"module"`
+	System        *sdk.SystemInfo  `json:"system"`
+	Theme         rpcplugin.Colors `json:"theme"`
+	TerminalWidth int              `json:"terminal_width"`
+	Remote        string           `json:"remote"`
 }
 
 type externalOutput struct {
@@ -36,29 +50,86 @@ func (m *externalModule) Name() string {
 	return m.name
 }
 
-func (m *externalModule) Render(_ *collectors.SystemInfo, styles theme.Styles) string {
-	output, err := m.run()
+func (m *externalModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
+	payload, ok := m.payload(info)
+	if !ok {
+		return ""
+	}
+	return formatExternal(payload, styles)
+}
+
+// Overflow wraps external module output, which is free-form text.
+func (m *externalModule) Overflow() string { return modules.OverflowWrap }
+
+// Data returns the module output as structured data for exports.
+func (m *externalModule) Data(info *collectors.SystemInfo) (modules.Data, bool) {
+	payload, ok := m.payload(info)
+	if !ok {
+		return modules.Data{}, false
+	}
+
+	var lines []string
+	for _, line := range payloadLines(payload) {
+		line = strings.TrimRight(line, "\r")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	data := modules.Data{
+		Label: payload.Label,
+		Value: payload.Value,
+		Icon:  payload.Icon,
+		Lines: lines,
+	}
+	if data.Label == "" && data.Value == "" && len(data.Lines) == 0 {
+		return modules.Data{}, false
+	}
+	return data, true
+}
+
+// payload runs the module and parses its output, treating anything that is
+// not a JSON object as raw text.
+func (m *externalModule) payload(info *collectors.SystemInfo) (externalOutput, bool) {
+	output, err := m.run(info)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: external module %s failed: %v\n", m.name, err)
-		return ""
+		return externalOutput{}, false
 	}
 
 	trimmed := strings.TrimSpace(output)
 	if trimmed == "" {
-		return ""
+		return externalOutput{}, false
 	}
 
 	if strings.HasPrefix(trimmed, "{") {
 		var payload externalOutput
 		if err := json.Unmarshal([]byte(trimmed), &payload); err == nil {
-			return formatExternal(payload, styles)
+			return payload, true
 		}
 	}
 
-	return formatExternal(externalOutput{Raw: trimmed}, styles)
+	return externalOutput{Raw: trimmed}, true
 }
 
-func (m *externalModule) run() (string, error) {
+// payloadLines returns the body lines of a payload, falling back to raw or
+// text output.
+func payloadLines(payload externalOutput) []string {
+	if len(payload.Lines) > 0 {
+		return payload.Lines
+	}
+	raw := payload.Raw
+	if raw == "" {
+		raw = payload.Text
+	}
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, "\n")
+}
+
+// run executes the module with the collected information as JSON on stdin
+// and its key fields in the environment.
+func (m *externalModule) run(info *collectors.SystemInfo) (string, error) {
 	timeout := m.timeout
 	if timeout <= 0 {
 		timeout = 250 * time.Millisecond
@@ -71,11 +142,24 @@ func (m *externalModule) run() (string, error) {
 	if err == nil && cwd != "" {
 		cmd.Dir = cwd
 	}
+	system := sdkInfo(info)
+	input, err := json.Marshal(externalInput{
+		Module:        m.name,
+		System:        system,
+		Theme:         rpcplugin.Colors(m.host.Colors),
+		TerminalWidth: m.host.Width,
+		Remote:        m.host.Remote,
+	})
+	if err != nil {
+		return "", err
+	}
+	cmd.Stdin = bytes.NewReader(input)
 	cmd.Env = append(os.Environ(),
 		"BUBBLEFETCH_FORMAT=json",
 		"BUBBLEFETCH_MODULE="+m.name,
 		"BUBBLEFETCH_CWD="+cmd.Dir,
 	)
+	cmd.Env = append(cmd.Env, externalEnv(system, m.host)...)
 
 	output, err := cmd.Output()
 	if ctx.Err() == context.DeadlineExceeded {
@@ -87,23 +171,53 @@ func (m *externalModule) run() (string, error) {
 	return string(output), nil
 }
 
+// externalEnv returns the key system fields, named as in the env export,
+// and the theme colors, terminal width and remote target as environment
+// variables.
+func externalEnv(system *sdk.SystemInfo, host *Host) []string {
+	vars := [][2]string{
+		{"OS", system.OS},
+		{"KERNEL", system.Kernel},
+		{"HOSTNAME", system.Hostname},
+		{"UPTIME", system.Uptime},
+		{"CPU", system.CPU},
+		{"MEMORY_USED_BYTES", strconv.FormatUint(system.Memory.Used, 10)},
+		{"MEMORY_TOTAL_BYTES", strconv.FormatUint(system.Memory.Total, 10)},
+		{"DISK_USED_BYTES", strconv.FormatUint(system.Disk.Used, 10)},
+		{"DISK_TOTAL_BYTES", strconv.FormatUint(system.Disk.Total, 10)},
+		{"SHELL", system.Shell},
+		{"TERMINAL", system.Terminal},
+		{"DE", system.DE},
+		{"WM", system.WM},
+		{"LOCAL_IP", system.LocalIP},
+		{"COLOR_PRIMARY", host.Colors.Primary},
+		{"COLOR_SECONDARY", host.Colors.Secondary},
+		{"COLOR_ACCENT", host.Colors.Accent},
+		{"COLOR_LABEL", host.Colors.Label},
+		{"COLOR_VALUE", host.Colors.Value},
+		{"COLOR_GOOD", host.Colors.Good},
+		{"COLOR_WARNING", host.Colors.Warning},
+		{"COLOR_CRITICAL", host.Colors.Critical},
+		{"COLUMNS", strconv.Itoa(host.Width)},
+		{"REMOTE", host.Remote},
+	}
+	if system.Battery.Present {
+		vars = append(vars, [2]string{"BATTERY_PERCENT", strconv.FormatFloat(system.Battery.Percentage, 'f', -1, 64)})
+	}
+	env := make([]string, len(vars))
+	for i, v := range vars {
+		env[i] = "BUBBLEFETCH_" + v[0] + "=" + v[1]
+	}
+	return env
+}
+
 func formatExternal(payload externalOutput, styles theme.Styles) string {
 	separator := payload.Separator
 	if separator == "" {
 		separator = ": "
 	}
 
-	lines := payload.Lines
-	if len(lines) == 0 {
-		raw := payload.Raw
-		if raw == "" {
-			raw = payload.Text
-		}
-		if raw != "" {
-			lines = strings.Split(raw, "\n")
-		}
-	}
-
+	lines := payloadLines(payload)
 	if len(lines) == 0 {
 		return ""
 	}
@@ -193,10 +307,11 @@ func (pm *PluginManager) LoadExternalPlugins(dir string) error {
 			name:    name,
 			path:    path,
 			timeout: pm.externalTimeout,
+			host:    &pm.host,
 		}
 	}
 
 	return nil
 }
 
-var _ modules.Module = (*externalModule)(nil)
+var _ modules.DataModule = (*externalModule)(nil)
@@ -10,8 +10,12 @@ import (
 	"github.com/howieduhzit/bubblefetch/internal/collectors"
 	"github.com/howieduhzit/bubblefetch/internal/ui/modules"
 	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
 )
 
+// sdkPackage is the import path of the plugin API.
+const sdkPackage = "github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
+
 // PluginModule wraps a plugin's render function to implement the Module interface
 type PluginModule struct {
 	name   string
@@ -30,6 +34,19 @@ func (p *PluginModule) Render(info *collectors.SystemInfo, styles theme.Styles)
 type PluginManager struct {
 	plugins         map[string]modules.Module
 	externalTimeout time.Duration
+	rpc             []*rpcModule
+	host            Host
+}
+
+// Host is what external modules and RPC plugins are told about the running
+// bubblefetch.
+type Host struct {
+	Version string
+	Colors  theme.Colors
+	// Config holds the plugin_config entries, by plugin name.
+	Config map[string]map[string]any
+	Remote string // Remote target; empty for the local system
+	Width  int    // Terminal width in columns, or 0 when unknown
 }
 
 // NewPluginManager creates a new plugin manager
@@ -73,48 +90,72 @@ func (pm *PluginManager) LoadPlugins(pluginDir string) error {
 	if err := pm.LoadExternalPlugins(filepath.Join(pluginDir, "external")); err != nil {
 		return err
 	}
+	if err := pm.LoadRPCPlugins(filepath.Join(pluginDir, "rpc")); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-// LoadPlugin loads a single plugin from the specified path
+// LoadPlugin loads a single plugin from the specified path. Plugins must be
+// built with the sdk package and export an APIVersion matching the host's.
 func (pm *PluginManager) LoadPlugin(path string) error {
 	p, err := plugin.Open(path)
 	if err != nil {
 		return fmt.Errorf("failed to open plugin: %w", err)
 	}
 
-	// Look for required symbols
-	nameSymbol, err := p.Lookup("ModuleName")
+	render, err := pluginRender(p)
 	if err != nil {
-		return fmt.Errorf("plugin missing ModuleName: %w", err)
+		return err
 	}
 
-	renderSymbol, err := p.Lookup("Render")
+	nameSymbol, err := p.Lookup("ModuleName")
 	if err != nil {
-		return fmt.Errorf("plugin missing Render function: %w", err)
+		return fmt.Errorf("plugin missing ModuleName: %w", err)
 	}
-
-	// Type assert to expected types
 	name, ok := nameSymbol.(*string)
 	if !ok {
 		return fmt.Errorf("ModuleName is not *string")
 	}
 
-	renderFunc, ok := renderSymbol.(func(*collectors.SystemInfo, theme.Styles) string)
-	if !ok {
-		return fmt.Errorf("Render is not the correct function type")
-	}
-
 	// Register plugin
 	pm.plugins[*name] = &PluginModule{
 		name:   *name,
-		render: renderFunc,
+		render: render,
 	}
 
 	return nil
 }
 
+// pluginRender checks the plugin's API version and returns its Render
+// function.
+func pluginRender(p *plugin.Plugin) (func(*collectors.SystemInfo, theme.Styles) string, error) {
+	renderSymbol, err := p.Lookup("Render")
+	if err != nil {
+		return nil, fmt.Errorf("plugin missing Render function: %w", err)
+	}
+
+	versionSymbol, err := p.Lookup("APIVersion")
+	if err != nil {
+		return nil, fmt.Errorf("plugin missing APIVersion; rebuild it with the %s package (var APIVersion = sdk.APIVersion)", sdkPackage)
+	}
+	version, ok := versionSymbol.(*int)
+	if !ok {
+		return nil, fmt.Errorf("APIVersion is not int")
+	}
+	if *version != sdk.APIVersion {
+		return nil, fmt.Errorf("plugin uses API version %d, but this bubblefetch supports version %d; rebuild it against this release", *version, sdk.APIVersion)
+	}
+	render, ok := renderSymbol.(func(*sdk.SystemInfo, sdk.Styles) string)
+	if !ok {
+		return nil, fmt.Errorf("Render is not func(*sdk.SystemInfo, sdk.Styles) string")
+	}
+	return func(info *collectors.SystemInfo, styles theme.Styles) string {
+		return render(sdkInfo(info), sdkStyles(styles))
+	}, nil
+}
+
 // GetPlugin retrieves a plugin by name
 func (pm *PluginManager) GetPlugin(name string) (modules.Module, bool) {
 	mod, ok := pm.plugins[name]
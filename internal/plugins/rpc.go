@@ -0,0 +1,409 @@
+package plugins
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/ui/modules"
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk/rpcplugin"
+)
+
+const (
+	// rpcStartTimeout bounds starting a plugin and its handshake, which may
+	// take longer than a render call.
+	rpcStartTimeout = 2 * time.Second
+	// rpcPingAfter is how long a plugin may sit idle, as in serve mode,
+	// before it is pinged ahead of the next render.
+	rpcPingAfter = 10 * time.Second
+	// rpcRestartDelay is how long the module is hidden after a plugin
+	// fails, before it is restarted. It doubles with each consecutive
+	// failure, up to rpcMaxRestartDelay.
+	rpcRestartDelay    = time.Second
+	rpcMaxRestartDelay = time.Minute
+)
+
+// rpcModule is a module served by a long-lived plugin process speaking
+// JSON-RPC on stdio. The process is started on first use and kept for the
+// rest of the run. One that fails is stopped and its module hidden until it
+// is restarted on a later render, after a delay that grows while it keeps
+// failing.
+type rpcModule struct {
+	name    string
+	path    string
+	timeout time.Duration
+	host    *rpcplugin.Host
+
+	mu       sync.Mutex
+	proc     *rpcProcess
+	failures int // consecutive failures
+	retryAt  time.Time
+	lastUsed time.Time
+	// The last output is reused while the system information is the same,
+	// so redraws don't call the plugin.
+	lastInfo   *collectors.SystemInfo
+	lastOutput externalOutput
+	lastOK     bool
+}
+
+func (m *rpcModule) Name() string {
+	return m.name
+}
+
+func (m *rpcModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
+	payload, ok := m.payload(info)
+	if !ok {
+		return ""
+	}
+	return formatExternal(payload, styles)
+}
+
+// Overflow wraps RPC plugin output, like external module output.
+func (m *rpcModule) Overflow() string { return modules.OverflowWrap }
+
+// Data returns the module output as structured data for exports.
+func (m *rpcModule) Data(info *collectors.SystemInfo) (modules.Data, bool) {
+	payload, ok := m.payload(info)
+	if !ok {
+		return modules.Data{}, false
+	}
+	data := modules.Data{
+		Label:  payload.Label,
+		Value:  payload.Value,
+		Icon:   payload.Icon,
+		Lines:  payload.Lines,
+		Source: "rpc",
+	}
+	if data.Label == "" && data.Value == "" && len(data.Lines) == 0 {
+		return modules.Data{}, false
+	}
+	return data, true
+}
+
+// payload asks the plugin to render the module for info.
+func (m *rpcModule) payload(info *collectors.SystemInfo) (externalOutput, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.lastInfo != nil && m.lastInfo == info {
+		return m.lastOutput, m.lastOK
+	}
+	if m.proc == nil && time.Now().Before(m.retryAt) {
+		return externalOutput{}, false
+	}
+
+	output, err := m.render(info)
+	if err != nil {
+		m.stop()
+		m.failures++
+		delay := restartDelay(m.failures)
+		m.retryAt = time.Now().Add(delay)
+		fmt.Fprintf(os.Stderr, "Warning: RPC plugin %s failed: %v; restarting it in %s\n", m.name, err, delay)
+		return externalOutput{}, false
+	}
+	m.failures = 0
+
+	payload := externalOutput{
+		Label:     output.Label,
+		Value:     output.Value,
+		Icon:      output.Icon,
+		Separator: output.Separator,
+		Lines:     output.Lines,
+	}
+	ok := payload.Label != "" || payload.Value != "" || len(payload.Lines) > 0
+	m.lastInfo, m.lastOutput, m.lastOK = info, payload, ok
+	return payload, ok
+}
+
+func (m *rpcModule) render(info *collectors.SystemInfo) (rpcplugin.Output, error) {
+	if m.proc == nil {
+		if err := m.start(); err != nil {
+			return rpcplugin.Output{}, err
+		}
+	} else if time.Since(m.lastUsed) > rpcPingAfter {
+		if err := m.proc.call(rpcplugin.MethodPing, nil, nil, m.timeout); err != nil {
+			return rpcplugin.Output{}, fmt.Errorf("health check: %w", err)
+		}
+	}
+
+	var output rpcplugin.Output
+	params := rpcplugin.RenderParams{Module: m.name, System: *sdkInfo(info)}
+	if err := m.proc.call(rpcplugin.MethodRender, params, &output, m.timeout); err != nil {
+		return rpcplugin.Output{}, err
+	}
+	m.lastUsed = time.Now()
+	return output, nil
+}
+
+// restartDelay is how long to wait before restarting a plugin that failed
+// failures times in a row.
+func restartDelay(failures int) time.Duration {
+	delay := rpcRestartDelay
+	for i := 1; i < failures && delay < rpcMaxRestartDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, rpcMaxRestartDelay)
+}
+
+// start runs the plugin and performs the handshake.
+func (m *rpcModule) start() error {
+	proc, err := startRPCProcess(m.path)
+	if err != nil {
+		return err
+	}
+	m.proc = proc
+
+	var result rpcplugin.InitializeResult
+	if err := proc.call(rpcplugin.MethodInitialize, m.host, &result, max(m.timeout, rpcStartTimeout)); err != nil {
+		return fmt.Errorf("handshake: %w", err)
+	}
+	if result.ProtocolVersion != rpcplugin.ProtocolVersion {
+		return fmt.Errorf("plugin uses protocol version %d, but this bubblefetch supports version %d; update it for this release", result.ProtocolVersion, rpcplugin.ProtocolVersion)
+	}
+	m.lastUsed = time.Now()
+	return nil
+}
+
+// stop asks the plugin to shut down and kills it if it doesn't exit in time.
+func (m *rpcModule) stop() {
+	if m.proc == nil {
+		return
+	}
+	m.proc.shutdown(m.timeout)
+	m.proc = nil
+}
+
+// Close stops the plugin process, if it was started.
+func (m *rpcModule) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stop()
+}
+
+// rpcProcess is a running plugin.
+type rpcProcess struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	responses chan rpcResponse
+	// exited is closed when the plugin's stdout is closed.
+	exited chan struct{}
+	nextID int
+}
+
+type rpcRequest struct {
+	Version string `json:"jsonrpc"`
+	ID      *int   `json:"id,omitempty"` // nil for notifications
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	ID     *int            `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+func startRPCProcess(path string) (*rpcProcess, error) {
+	cmd := exec.Command(path)
+	if cwd, err := os.Getwd(); err == nil {
+		cmd.Dir = cwd
+	}
+	cmd.Env = append(os.Environ(), "BUBBLEFETCH_PROTOCOL=jsonrpc")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &rpcProcess{
+		cmd:       cmd,
+		stdin:     stdin,
+		responses: make(chan rpcResponse, 1),
+		exited:    make(chan struct{}),
+	}
+	go p.read(stdout)
+	return p, nil
+}
+
+// read delivers the responses the plugin writes, one per line. Lines that
+// are not responses to a request are ignored.
+func (p *rpcProcess) read(stdout io.Reader) {
+	defer close(p.exited)
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var resp rpcResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil || resp.ID == nil {
+			continue
+		}
+		select {
+		case p.responses <- resp:
+		case <-time.After(time.Second):
+			// Nobody is waiting for a late response.
+		}
+	}
+}
+
+// call sends a request and decodes the result into result, which may be nil.
+func (p *rpcProcess) call(method string, params, result any, timeout time.Duration) error {
+	p.nextID++
+	id := p.nextID
+	if err := p.send(rpcRequest{Version: "2.0", ID: &id, Method: method, Params: params}); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case resp := <-p.responses:
+			if *resp.ID != id {
+				continue
+			}
+			if resp.Error != nil {
+				return fmt.Errorf("%s: %w", method, resp.Error)
+			}
+			if result == nil {
+				return nil
+			}
+			if err := json.Unmarshal(resp.Result, result); err != nil {
+				return fmt.Errorf("%s: invalid result: %w", method, err)
+			}
+			return nil
+		case <-p.exited:
+			return fmt.Errorf("%s: plugin exited", method)
+		case <-timer.C:
+			return fmt.Errorf("%s: timeout after %s", method, timeout)
+		}
+	}
+}
+
+// send writes a message as one line. A plugin that exited is reported as
+// such rather than as a broken pipe.
+func (p *rpcProcess) send(req rpcRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if _, err := p.stdin.Write(append(data, '\n')); err != nil {
+		select {
+		case <-p.exited:
+			return fmt.Errorf("plugin exited")
+		case <-time.After(100 * time.Millisecond):
+			return err
+		}
+	}
+	return nil
+}
+
+// shutdown sends the shutdown notification and closes stdin, then kills the
+// plugin if it is still running after timeout.
+func (p *rpcProcess) shutdown(timeout time.Duration) {
+	_ = p.send(rpcRequest{Version: "2.0", Method: rpcplugin.MethodShutdown})
+	_ = p.stdin.Close()
+
+	done := make(chan struct{})
+	go func() {
+		_ = p.cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		_ = p.cmd.Process.Kill()
+		<-done
+	}
+}
+
+// LoadRPCPlugins registers the executables in the rpc directory. Each one
+// provides the module named after its file, without the extension.
+func (pm *PluginManager) LoadRPCPlugins(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0111 == 0 {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		mod := &rpcModule{
+			name:    name,
+			path:    filepath.Join(dir, entry.Name()),
+			timeout: pm.externalTimeout,
+			host:    pm.rpcHost(name),
+		}
+		pm.plugins[name] = mod
+		pm.rpc = append(pm.rpc, mod)
+	}
+
+	return nil
+}
+
+// SetHost sets what external modules and RPC plugins are told about this
+// run. Call it before loading plugins.
+func (pm *PluginManager) SetHost(host Host) {
+	host.Colors = host.Colors.WithDefaults()
+	pm.host = host
+}
+
+func (pm *PluginManager) rpcHost(name string) *rpcplugin.Host {
+	config := pm.host.Config[name]
+	if config == nil {
+		config = map[string]any{}
+	}
+	return &rpcplugin.Host{
+		ProtocolVersion: rpcplugin.ProtocolVersion,
+		Version:         pm.host.Version,
+		Theme:           rpcplugin.Colors(pm.host.Colors),
+		Config:          config,
+		Remote:          pm.host.Remote,
+		TerminalWidth:   pm.host.Width,
+	}
+}
+
+// Close stops the RPC plugins that were started.
+func (pm *PluginManager) Close() {
+	for _, mod := range pm.rpc {
+		mod.Close()
+	}
+}
+
+var _ modules.DataModule = (*rpcModule)(nil)
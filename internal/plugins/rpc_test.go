@@ -0,0 +1,179 @@
+package plugins
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk/rpcplugin"
+)
+
+// pluginModeEnv makes the test binary act as an RPC plugin, so that the host
+// side can be tested against a real process.
+const pluginModeEnv = "BUBBLEFETCH_TEST_RPC_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(pluginModeEnv); mode != "" {
+		testPlugin(mode)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// testPlugin serves one of these plugins:
+//
+//	ok     renders the hostname
+//	slow   takes longer to render than any test timeout
+//	exit   exits on render
+//	old    answers the handshake with another protocol version
+func testPlugin(mode string) {
+	if mode == "old" {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			var req struct {
+				ID *int `json:"id"`
+			}
+			if json.Unmarshal(scanner.Bytes(), &req) != nil || req.ID == nil {
+				continue
+			}
+			json.NewEncoder(os.Stdout).Encode(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      *req.ID,
+				"result":  rpcplugin.InitializeResult{ProtocolVersion: rpcplugin.ProtocolVersion + 1},
+			})
+		}
+		return
+	}
+	rpcplugin.Serve(rpcplugin.Plugin{
+		Render: func(req rpcplugin.Request) (rpcplugin.Output, error) {
+			switch mode {
+			case "slow":
+				time.Sleep(10 * time.Second)
+			case "exit":
+				os.Exit(1)
+			}
+			return rpcplugin.Output{Label: "Host", Value: req.System.Hostname}, nil
+		},
+	})
+}
+
+// testRPCModule returns a module served by the test binary in mode. The
+// plugin is stopped when the test ends.
+func testRPCModule(t *testing.T, mode string) *rpcModule {
+	t.Helper()
+	t.Setenv(pluginModeEnv, mode)
+	path, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &rpcModule{
+		name:    "test",
+		path:    path,
+		timeout: 2 * time.Second,
+		host:    &rpcplugin.Host{ProtocolVersion: rpcplugin.ProtocolVersion},
+	}
+	t.Cleanup(m.Close)
+	return m
+}
+
+func TestRPCModuleRender(t *testing.T) {
+	m := testRPCModule(t, "ok")
+	info := &collectors.SystemInfo{Hostname: "build-box"}
+
+	data, ok := m.Data(info)
+	if !ok {
+		t.Fatal("Data() hid the module")
+	}
+	if data.Label != "Host" || data.Value != "build-box" || data.Source != "rpc" {
+		t.Errorf("Data() = %+v", data)
+	}
+
+	// A health check ahead of a render after a long idle.
+	m.lastUsed = time.Now().Add(-2 * rpcPingAfter)
+	if data, ok := m.Data(&collectors.SystemInfo{Hostname: "other"}); !ok || data.Value != "other" {
+		t.Errorf("Data() after idle = %+v, %v", data, ok)
+	}
+}
+
+func TestRPCModuleFailure(t *testing.T) {
+	tests := []struct {
+		mode    string
+		wantErr string
+	}{
+		{mode: "slow", wantErr: "render: timeout after 200ms"},
+		{mode: "exit", wantErr: "render: plugin exited"},
+		{mode: "old", wantErr: "plugin uses protocol version 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			m := testRPCModule(t, tt.mode)
+			m.timeout = 200 * time.Millisecond
+			_, err := m.render(&collectors.SystemInfo{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("render() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRPCModuleRestart(t *testing.T) {
+	m := testRPCModule(t, "exit")
+	info := &collectors.SystemInfo{Hostname: "build-box"}
+
+	if _, ok := m.payload(info); ok {
+		t.Fatal("payload() succeeded with a plugin that exits")
+	}
+	if m.proc != nil || m.failures != 1 {
+		t.Fatalf("after a failure: proc = %v, failures = %d", m.proc, m.failures)
+	}
+
+	// The module stays hidden until the restart delay has passed.
+	t.Setenv(pluginModeEnv, "ok")
+	if _, ok := m.payload(&collectors.SystemInfo{}); ok || m.proc != nil {
+		t.Fatal("plugin restarted before the restart delay")
+	}
+
+	m.retryAt = time.Now()
+	payload, ok := m.payload(info)
+	if !ok || payload.Value != "build-box" {
+		t.Fatalf("payload() after the restart delay = %+v, %v", payload, ok)
+	}
+	if m.failures != 0 {
+		t.Errorf("failures = %d after a successful render, want 0", m.failures)
+	}
+}
+
+func TestRestartDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{7, time.Minute},
+		{100, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := restartDelay(tt.failures); got != tt.want {
+			t.Errorf("restartDelay(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestRPCProcessShutdown(t *testing.T) {
+	m := testRPCModule(t, "ok")
+	if err := m.start(); err != nil {
+		t.Fatal(err)
+	}
+	proc := m.proc
+	m.Close()
+	// The plugin exits on its own after shutdown rather than being killed.
+	if state := proc.cmd.ProcessState; state == nil || state.ExitCode() != 0 {
+		t.Fatalf("plugin state after Close = %v, want a clean exit", state)
+	}
+}
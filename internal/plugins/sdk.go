@@ -0,0 +1,60 @@
+package plugins
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
+)
+
+// sdkInfo converts collected information to its plugin API form.
+func sdkInfo(info *collectors.SystemInfo) *sdk.SystemInfo {
+	if info == nil {
+		return &sdk.SystemInfo{}
+	}
+	network := make([]sdk.NetworkInterface, len(info.Network))
+	for i, iface := range info.Network {
+		network[i] = sdk.NetworkInterface(iface)
+	}
+	return &sdk.SystemInfo{
+		OS:         info.OS,
+		Kernel:     info.Kernel,
+		Hostname:   info.Hostname,
+		Uptime:     info.Uptime,
+		CPU:        info.CPU,
+		Memory:     sdk.Usage(info.Memory),
+		Disk:       sdk.Usage(info.Disk),
+		Shell:      info.Shell,
+		Terminal:   info.Terminal,
+		Resolution: info.Resolution,
+		DE:         info.DE,
+		WM:         info.WM,
+		Theme:      info.Theme,
+		Icons:      info.Icons,
+		GPU:        append([]string{}, info.GPU...),
+		Network:    network,
+		Battery:    sdk.Battery(info.Battery),
+		LocalIP:    info.LocalIP,
+		PublicIP:   info.PublicIP,
+	}
+}
+
+// sdkStyles converts theme styles to their plugin API form.
+func sdkStyles(styles theme.Styles) sdk.Styles {
+	s := sdk.Styles{
+		Title:     styles.Title,
+		Label:     styles.Label,
+		Value:     styles.Value,
+		Separator: styles.Separator,
+		Primary:   styles.ASCII,
+		Secondary: lipgloss.NewStyle(),
+		Accent:    lipgloss.NewStyle(),
+		Good:      styles.Good,
+		Warning:   styles.Warning,
+		Critical:  styles.Critical,
+	}
+	if len(styles.ASCIIColors) >= 3 {
+		s.Primary, s.Secondary, s.Accent = styles.ASCIIColors[0], styles.ASCIIColors[1], styles.ASCIIColors[2]
+	}
+	return s
+}
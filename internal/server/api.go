@@ -0,0 +1,152 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"github.com/howieduhzit/bubblefetch/internal/export"
+	"github.com/howieduhzit/bubblefetch/internal/ui/modules"
+)
+
+var themeNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	info, _, err := s.snapshot()
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	var mods []config.ModuleConfig
+	for _, name := range splitList(r.URL.Query().Get("modules")) {
+		mod := s.opts.Config.Module(name)
+		if modules.Factory(mod) == nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown module %q", name))
+			return
+		}
+		mods = append(mods, mod)
+	}
+
+	doc := export.NewDocument(info, export.Source{
+		Version:   s.opts.Version,
+		Collector: s.opts.Collector,
+	}, mods)
+	if collectedAt := s.collectedTime(); !collectedAt.IsZero() {
+		doc.Timestamp = collectedAt.UTC().Truncate(time.Second)
+	}
+	writeJSON(w, http.StatusOK, doc)
+}
+
+func (s *Server) handleRender(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	cfg := *s.opts.Config
+	if themeName := query.Get("theme"); themeName != "" {
+		if !themeNamePattern.MatchString(themeName) {
+			writeError(w, http.StatusBadRequest, "invalid theme name")
+			return
+		}
+		cfg.Theme = themeName
+	}
+	if names := splitList(query.Get("modules")); len(names) > 0 {
+		mods := make([]config.ModuleConfig, len(names))
+		for i, name := range names {
+			mods[i] = s.opts.Config.Module(name)
+			if modules.Factory(mods[i]) == nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown module %q", name))
+				return
+			}
+		}
+		cfg.Modules = mods
+	}
+	if value := query.Get("scale"); value != "" {
+		scale, err := strconv.ParseFloat(value, 64)
+		if err != nil || scale <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid scale")
+			return
+		}
+		cfg.Image.Scale = scale
+	}
+
+	info, _, err := s.snapshot()
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	exporter, err := export.NewImageExporter(info, &cfg)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	exporter.SetSource(export.Source{Version: s.opts.Version, Collector: s.opts.Collector})
+
+	var buf bytes.Buffer
+	var contentType string
+	switch format := query.Get("format"); format {
+	case "", "svg":
+		contentType = "image/svg+xml"
+		err = exporter.WriteSVG(&buf)
+	case "png":
+		contentType = "image/png"
+		err = exporter.WritePNG(&buf)
+	case "html":
+		contentType = "text/html; charset=utf-8"
+		err = exporter.WriteHTML(&buf)
+	default:
+		writeError(w, http.StatusBadRequest, "unknown format "+format+" (use svg, png, or html)")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Write(buf.Bytes())
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"status":  "ok",
+		"version": s.opts.Version,
+	})
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+type label struct {
+	name  string
+	value string
+}
+
+// metricWriter writes Prometheus text exposition, emitting HELP/TYPE once per
+// metric family.
+type metricWriter struct {
+	b           strings.Builder
+	base        []label
+	current     string
+	openMetrics bool
+}
+
+// family starts a gauge family. Names ending in _info are declared with the
+// info type in OpenMetrics, which names the family without the suffix.
+func (w *metricWriter) family(name, help string) {
+	w.current = name
+	declared, kind := name, "gauge"
+	if w.openMetrics && strings.HasSuffix(name, "_info") {
+		declared, kind = strings.TrimSuffix(name, "_info"), "info"
+	}
+	fmt.Fprintf(&w.b, "# HELP %s %s\n", declared, help)
+	fmt.Fprintf(&w.b, "# TYPE %s %s\n", declared, kind)
+}
+
+func (w *metricWriter) sample(value float64, extra ...label) {
+	labels := append(append([]label{}, w.base...), extra...)
+	w.b.WriteString(w.current)
+	if len(labels) > 0 {
+		w.b.WriteString("{")
+		for i, l := range labels {
+			if i > 0 {
+				w.b.WriteString(",")
+			}
+			w.b.WriteString(l.name)
+			w.b.WriteString(`="`)
+			w.b.WriteString(escapeLabel(l.value))
+			w.b.WriteString(`"`)
+		}
+		w.b.WriteString("}")
+	}
+	w.b.WriteString(" ")
+	w.b.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	w.b.WriteString("\n")
+}
+
+// formatMetrics renders info as Prometheus metrics. Numeric fields become
+// gauges; string fields are exposed through info-style metrics valued 1.
+func formatMetrics(info *collectors.SystemInfo, duration time.Duration, err error, version string, openMetrics bool) string {
+	w := &metricWriter{openMetrics: openMetrics}
+
+	w.family("bubblefetch_build_info", "bubblefetch build information.")
+	w.sample(1, label{"version", version})
+
+	w.family("bubblefetch_up", "Whether the last collection succeeded.")
+	if err != nil || info == nil {
+		w.sample(0)
+		if openMetrics {
+			w.b.WriteString("# EOF\n")
+		}
+		return w.b.String()
+	}
+	w.sample(1)
+
+	w.base = []label{{"host", info.Hostname}, {"os", info.OS}}
+
+	w.family("bubblefetch_collect_duration_seconds", "Time taken by the last collection.")
+	w.sample(duration.Seconds())
+
+	w.family("bubblefetch_info", "Static system information.")
+	w.sample(1,
+		label{"kernel", info.Kernel},
+		label{"cpu", info.CPU},
+		label{"shell", info.Shell},
+		label{"terminal", info.Terminal},
+		label{"de", info.DE},
+		label{"wm", info.WM},
+		label{"local_ip", info.LocalIP},
+		label{"public_ip", info.PublicIP},
+	)
+
+	if len(info.GPU) > 0 {
+		w.family("bubblefetch_gpu_info", "Detected GPUs.")
+		for i, gpu := range info.GPU {
+			w.sample(1, label{"index", strconv.Itoa(i)}, label{"gpu", gpu})
+		}
+	}
+
+	if len(info.Network) > 0 {
+		w.family("bubblefetch_network_info", "Network interfaces and addresses.")
+		for _, iface := range info.Network {
+			w.sample(1,
+				label{"interface", iface.Interface},
+				label{"ipv4", iface.IPv4},
+				label{"ipv6", iface.IPv6},
+				label{"mac", iface.MAC},
+			)
+		}
+	}
+
+	if seconds, ok := parseUptimeSeconds(info.Uptime); ok {
+		w.family("bubblefetch_uptime_seconds", "System uptime in seconds (minute resolution).")
+		w.sample(float64(seconds))
+	}
+
+	if info.Memory.Total > 0 {
+		w.family("bubblefetch_memory_used_bytes", "Used memory in bytes.")
+		w.sample(float64(info.Memory.Used))
+		w.family("bubblefetch_memory_total_bytes", "Total memory in bytes.")
+		w.sample(float64(info.Memory.Total))
+	}
+
+	if info.Disk.Total > 0 {
+		w.family("bubblefetch_disk_used_bytes", "Used space on the root filesystem in bytes.")
+		w.sample(float64(info.Disk.Used))
+		w.family("bubblefetch_disk_total_bytes", "Size of the root filesystem in bytes.")
+		w.sample(float64(info.Disk.Total))
+	}
+
+	w.family("bubblefetch_battery_present", "Whether a battery is present.")
+	w.sample(boolValue(info.Battery.Present))
+	if info.Battery.Present {
+		w.family("bubblefetch_battery_percent", "Battery charge percentage.")
+		w.sample(info.Battery.Percentage)
+		w.family("bubblefetch_battery_charging", "Whether the battery is charging.")
+		w.sample(boolValue(info.Battery.IsCharging))
+	}
+
+	if len(info.ModuleCosts) > 0 {
+		w.family("bubblefetch_module_collect_seconds", "Per-module collection cost.")
+		for _, cost := range info.ModuleCosts {
+			w.sample(cost.DurationMS/1000, label{"module", cost.Name})
+		}
+	}
+
+	if openMetrics {
+		w.b.WriteString("# EOF\n")
+	}
+	return w.b.String()
+}
+
+// parseUptimeSeconds parses the "1d 2h 3m" form produced by the collectors.
+func parseUptimeSeconds(uptime string) (int64, bool) {
+	fields := strings.Fields(uptime)
+	if len(fields) == 0 {
+		return 0, false
+	}
+
+	var total int64
+	for _, field := range fields {
+		if len(field) < 2 {
+			return 0, false
+		}
+		value, err := strconv.ParseInt(field[:len(field)-1], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		switch field[len(field)-1] {
+		case 'd':
+			total += value * 86400
+		case 'h':
+			total += value * 3600
+		case 'm':
+			total += value * 60
+		default:
+			return 0, false
+		}
+	}
+	return total, true
+}
+
+func boolValue(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func escapeLabel(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
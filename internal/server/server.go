@@ -0,0 +1,203 @@
+package server
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+)
+
+// Options configures the HTTP server.
+type Options struct {
+	// MinInterval is the minimum time between collections; scrapes arriving
+	// sooner reuse the previous result.
+	MinInterval time.Duration
+	// Version is reported in bubblefetch_build_info and /v1/info.
+	Version string
+	// Collector names the collector in /v1/info documents.
+	Collector string
+	// Config supplies the theme and modules used by /v1/render.
+	Config *config.Config
+	// Token, when set, must be sent as "Authorization: Bearer <token>".
+	Token string
+	// Allow restricts clients to these IPs or CIDRs; empty allows all.
+	Allow []string
+}
+
+// Server exposes collected system info over HTTP.
+type Server struct {
+	collector collectors.Collector
+	opts      Options
+	allow     []*net.IPNet
+
+	mu          sync.Mutex
+	info        *collectors.SystemInfo
+	err         error
+	collectedAt time.Time
+	duration    time.Duration
+}
+
+// New creates a server that collects with collector on demand.
+func New(collector collectors.Collector, opts Options) (*Server, error) {
+	allow, err := parseAllowList(opts.Allow)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Config == nil {
+		opts.Config = config.NewDefault()
+	}
+	return &Server{
+		collector: collector,
+		opts:      opts,
+		allow:     allow,
+	}, nil
+}
+
+// Handler returns the HTTP routes served by bubblefetch.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.authorized(s.handleMetrics))
+	mux.HandleFunc("/v1/info", s.authorized(s.handleInfo))
+	mux.HandleFunc("/v1/render", s.authorized(s.handleRender))
+	mux.HandleFunc("/healthz", s.handleHealth)
+	mux.HandleFunc("/", s.handleIndex)
+	return s.allowed(mux)
+}
+
+// allowed rejects clients outside the allow list.
+func (s *Server) allowed(next http.Handler) http.Handler {
+	if len(s.allow) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ip := net.ParseIP(host)
+		for _, network := range s.allow {
+			if ip != nil && network.Contains(ip) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		writeError(w, http.StatusForbidden, "client address not allowed")
+	})
+}
+
+// authorized requires the bearer token when one is configured.
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	if s.opts.Token == "" {
+		return next
+	}
+	expected := []byte("Bearer " + s.opts.Token)
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="bubblefetch"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func parseAllowList(entries []string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid allow entry %q", entry)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allow entry %q: %w", entry, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// ListenAndServe serves the handler on addr until it fails.
+func (s *Server) ListenAndServe(addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
+
+// snapshot returns the cached info, collecting again once MinInterval has
+// passed. Concurrent scrapes share a single collection.
+func (s *Server) snapshot() (*collectors.SystemInfo, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.collectedAt.IsZero() && time.Since(s.collectedAt) < s.opts.MinInterval {
+		return s.info, s.duration, s.err
+	}
+
+	start := time.Now()
+	info, err := s.collector.Collect()
+	s.duration = time.Since(start)
+	s.collectedAt = time.Now()
+	s.err = err
+	if err == nil {
+		s.info = info
+	}
+	return s.info, s.duration, s.err
+}
+
+// collectedTime reports when the cached info was collected.
+func (s *Server) collectedTime() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.collectedAt
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	info, duration, err := s.snapshot()
+
+	openMetrics := strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text")
+	if openMetrics {
+		w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	}
+
+	fmt.Fprint(w, formatMetrics(info, duration, err, s.opts.Version, openMetrics))
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, `<html><head><title>bubblefetch</title></head><body><h1>bubblefetch</h1><ul>`+
+		`<li><a href="/v1/info">/v1/info</a></li>`+
+		`<li><a href="/v1/render">/v1/render</a></li>`+
+		`<li><a href="/metrics">/metrics</a></li>`+
+		`<li><a href="/healthz">/healthz</a></li>`+
+		`</ul></body></html>`)
+}
@@ -0,0 +1,167 @@
+package snapshot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+// Change kinds reported by Diff.
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Change describes one field that differs between two snapshots.
+type Change struct {
+	Field string `json:"field"`
+	Kind  string `json:"kind"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+	// Bytes marks memory and disk fields so renderers can humanize them.
+	Bytes bool `json:"-"`
+}
+
+// Side identifies one input of a diff.
+type Side struct {
+	Label     string    `json:"label"`
+	Timestamp time.Time `json:"timestamp"`
+	Host      string    `json:"host"`
+}
+
+// Result is the outcome of comparing two snapshots.
+type Result struct {
+	From    Side     `json:"from"`
+	To      Side     `json:"to"`
+	Changes []Change `json:"changes"`
+}
+
+// Compare diffs two snapshots field by field. ModuleCosts are ignored since
+// timings differ on every run.
+func Compare(from, to *Snapshot) Result {
+	result := Result{
+		From:    sideOf(from),
+		To:      sideOf(to),
+		Changes: Diff(from.Info, to.Info),
+	}
+	if result.Changes == nil {
+		result.Changes = []Change{}
+	}
+	return result
+}
+
+func sideOf(snap *Snapshot) Side {
+	label := snap.Path
+	if label == "" {
+		label = "live"
+	}
+	return Side{
+		Label:     label,
+		Timestamp: snap.Timestamp,
+		Host:      snap.Host,
+	}
+}
+
+// Diff compares two SystemInfo values and returns changes sorted by field.
+func Diff(a, b *collectors.SystemInfo) []Change {
+	before := flatten(a)
+	after := flatten(b)
+
+	fields := make(map[string]bool, len(before)+len(after))
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+
+	var changes []Change
+	for field := range fields {
+		oldValue, hadOld := before[field]
+		newValue, hasNew := after[field]
+		change := Change{
+			Field: field,
+			Old:   oldValue,
+			New:   newValue,
+			Bytes: isBytesField(field),
+		}
+		switch {
+		case hadOld && !hasNew:
+			change.Kind = Removed
+		case !hadOld && hasNew:
+			change.Kind = Added
+		case oldValue != newValue:
+			change.Kind = Changed
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+func isBytesField(field string) bool {
+	switch field {
+	case "Memory.Used", "Memory.Total", "Disk.Used", "Disk.Total":
+		return true
+	}
+	return false
+}
+
+// flatten turns info into "Path.To.Field" -> value pairs, skipping empty
+// values so that absent data shows up as added or removed.
+func flatten(info *collectors.SystemInfo) map[string]string {
+	values := make(map[string]string)
+	if info == nil {
+		return values
+	}
+	flattenValue("", reflect.ValueOf(*info), values)
+	return values
+}
+
+func flattenValue(prefix string, v reflect.Value, values map[string]string) {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			name := t.Field(i).Name
+			if prefix == "" && name == "ModuleCosts" {
+				continue
+			}
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			flattenValue(name, v.Field(i), values)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			flattenValue(fmt.Sprintf("%s[%s]", prefix, elementKey(v.Index(i), i)), v.Index(i), values)
+		}
+	default:
+		if v.IsZero() {
+			return
+		}
+		values[prefix] = fmt.Sprint(v.Interface())
+	}
+}
+
+// elementKey identifies a list element across snapshots. Network interfaces
+// are keyed by name, so a reordered list is not reported as changed; other
+// elements by position.
+func elementKey(v reflect.Value, index int) string {
+	if v.Kind() == reflect.Struct {
+		if name := v.FieldByName("Interface"); name.IsValid() && name.Kind() == reflect.String && name.String() != "" {
+			return name.String()
+		}
+	}
+	return strconv.Itoa(index)
+}
@@ -0,0 +1,86 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+func TestFlatten(t *testing.T) {
+	info := &collectors.SystemInfo{
+		OS:     "Arch Linux",
+		Memory: collectors.MemoryInfo{Used: 1024, Total: 4096},
+		GPU:    []string{"AMD Radeon RX 6800", ""},
+		Network: []collectors.NetworkInfo{
+			{Interface: "eth0", IPv4: "192.168.1.10"},
+			{IPv4: "10.0.0.2"},
+		},
+		Battery:     collectors.BatteryInfo{Present: true, Percentage: 87.5},
+		ModuleCosts: []collectors.ModuleCost{{Name: "os", DurationMS: 0.25}},
+	}
+	want := map[string]string{
+		"OS":                      "Arch Linux",
+		"Memory.Used":             "1024",
+		"Memory.Total":            "4096",
+		"GPU[0]":                  "AMD Radeon RX 6800",
+		"Network[eth0].IPv4":      "192.168.1.10",
+		"Network[eth0].Interface": "eth0",
+		"Network[1].IPv4":         "10.0.0.2",
+		"Battery.Present":         "true",
+		"Battery.Percentage":      "87.5",
+	}
+	if got := flatten(info); !reflect.DeepEqual(got, want) {
+		t.Errorf("flatten() = %v, want %v", got, want)
+	}
+	if got := flatten(nil); len(got) != 0 {
+		t.Errorf("flatten(nil) = %v, want no values", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := &collectors.SystemInfo{
+		OS:     "Arch Linux",
+		Kernel: "6.8.1",
+		Disk:   collectors.DiskInfo{Used: 100, Total: 1000},
+		Network: []collectors.NetworkInfo{
+			{Interface: "eth0", IPv4: "192.168.1.10"},
+			{Interface: "wlan0", IPv4: "192.168.1.20"},
+		},
+		ModuleCosts: []collectors.ModuleCost{{Name: "os", DurationMS: 1}},
+	}
+	after := &collectors.SystemInfo{
+		OS:       "Arch Linux",
+		Kernel:   "6.9.0",
+		Hostname: "box",
+		Disk:     collectors.DiskInfo{Used: 200, Total: 1000},
+		// Reordered, with wlan0 gone.
+		Network: []collectors.NetworkInfo{
+			{Interface: "eth0", IPv4: "192.168.1.10"},
+		},
+		ModuleCosts: []collectors.ModuleCost{{Name: "os", DurationMS: 2}},
+	}
+	want := []Change{
+		{Field: "Disk.Used", Kind: Changed, Old: "100", New: "200", Bytes: true},
+		{Field: "Hostname", Kind: Added, New: "box"},
+		{Field: "Kernel", Kind: Changed, Old: "6.8.1", New: "6.9.0"},
+		{Field: "Network[wlan0].IPv4", Kind: Removed, Old: "192.168.1.20"},
+		{Field: "Network[wlan0].Interface", Kind: Removed, Old: "wlan0"},
+	}
+	if got := Diff(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := Diff(before, before); got != nil {
+		t.Errorf("Diff() of equal info = %+v, want none", got)
+	}
+}
+
+func TestDiffReorderedInterfaces(t *testing.T) {
+	eth := collectors.NetworkInfo{Interface: "eth0", IPv4: "192.168.1.10"}
+	wlan := collectors.NetworkInfo{Interface: "wlan0", IPv4: "192.168.1.20"}
+	a := &collectors.SystemInfo{Network: []collectors.NetworkInfo{eth, wlan}}
+	b := &collectors.SystemInfo{Network: []collectors.NetworkInfo{wlan, eth}}
+	if got := Diff(a, b); got != nil {
+		t.Errorf("Diff() of reordered interfaces = %+v, want none", got)
+	}
+}
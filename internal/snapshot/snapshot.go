@@ -0,0 +1,193 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+)
+
+// timeLayout names snapshot files. Microseconds keep saves within the same
+// second apart, and the fixed width keeps lexical order chronological.
+const timeLayout = "20060102T150405.000000Z"
+
+// Snapshot is a timestamped SystemInfo stored on disk.
+type Snapshot struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Host      string                 `json:"host"`
+	Source    string                 `json:"source,omitempty"`
+	Info      *collectors.SystemInfo `json:"info"`
+	Path      string                 `json:"-"`
+}
+
+// Dir returns the snapshot directory, $XDG_DATA_HOME/bubblefetch/snapshots or
+// ~/.local/share/bubblefetch/snapshots.
+func Dir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "bubblefetch", "snapshots"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "bubblefetch", "snapshots"), nil
+}
+
+// Save writes info as a new snapshot and returns its path. source records the
+// remote target, or is empty for local collection.
+func Save(info *collectors.SystemInfo, source string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	snap := Snapshot{
+		Timestamp: time.Now().UTC(),
+		Host:      info.Hostname,
+		Source:    source,
+		Info:      info,
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	// Never overwrite a snapshot: if the name is taken, name the file a
+	// microsecond later, which still sorts after the existing one.
+	for stamp := snap.Timestamp; ; stamp = stamp.Add(time.Microsecond) {
+		name := stamp.Format(timeLayout)
+		if host := sanitizeName(snap.Host); host != "" {
+			name += "-" + host
+		}
+		path := filepath.Join(dir, name+".json")
+		err := writeNew(path, data)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to write snapshot: %w", err)
+		}
+		return path, nil
+	}
+}
+
+// writeNew writes data to a file that must not exist yet.
+func writeNew(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// List returns stored snapshot paths, oldest first.
+func List() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	// Names start with a UTC timestamp, so lexical order is chronological.
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Load resolves ref and reads the snapshot. ref may be a file path, a file
+// name in the snapshot directory (with or without .json), "latest" or
+// "previous".
+func Load(ref string) (*Snapshot, error) {
+	path, err := resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	if snap.Info == nil {
+		return nil, fmt.Errorf("invalid snapshot %s: missing info", path)
+	}
+	snap.Path = path
+	return &snap, nil
+}
+
+func resolve(ref string) (string, error) {
+	switch ref {
+	case "latest", "previous":
+		paths, err := List()
+		if err != nil {
+			return "", err
+		}
+		index := len(paths) - 1
+		if ref == "previous" {
+			index--
+		}
+		if index < 0 {
+			return "", fmt.Errorf("not enough snapshots saved for %q", ref)
+		}
+		return paths[index], nil
+	}
+
+	if _, err := os.Stat(ref); err == nil {
+		return ref, nil
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	for _, candidate := range []string{ref, ref + ".json"} {
+		path := filepath.Join(dir, candidate)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("snapshot %q not found", ref)
+}
+
+func sanitizeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/howieduhzit/bubblefetch/internal/ui/logo"
 )
 
 const (
@@ -46,45 +48,7 @@ func FetchAndConvertLogo(logoURI string) (string, error) {
 		return getDefaultTokenASCII(), nil
 	}
 
-	return imageToASCII(img, defaultASCIIWidth, defaultASCIIHeight), nil
-}
-
-// imageToASCII converts an image to ASCII art
-func imageToASCII(img image.Image, width, height int) string {
-	bounds := img.Bounds()
-	asciiChars := []rune(" .:-=+*#%@")
-
-	var result strings.Builder
-
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			// Map ASCII coordinates to image coordinates
-			imgX := bounds.Min.X + (x * bounds.Dx() / width)
-			imgY := bounds.Min.Y + (y * bounds.Dy() / height)
-
-			// Get pixel color
-			r, g, b, _ := img.At(imgX, imgY).RGBA()
-
-			// Convert to grayscale (0-255)
-			gray := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 256.0
-
-			// Map grayscale to ASCII character
-			charIndex := int(gray / 255.0 * float64(len(asciiChars)-1))
-			if charIndex < 0 {
-				charIndex = 0
-			}
-			if charIndex >= len(asciiChars) {
-				charIndex = len(asciiChars) - 1
-			}
-
-			result.WriteRune(asciiChars[charIndex])
-		}
-		if y < height-1 {
-			result.WriteRune('\n')
-		}
-	}
-
-	return result.String()
+	return logo.ASCII(img, defaultASCIIWidth, defaultASCIIHeight), nil
 }
 
 // getDefaultTokenASCII returns a default ASCII art for tokens when logo is unavailable
@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+)
+
+// Background modes accepted by --background and the background config option.
+const (
+	BackgroundAuto  = "auto"
+	BackgroundLight = theme.BackgroundLight
+	BackgroundDark  = theme.BackgroundDark
+)
+
+// backgroundQueryTimeout bounds how long the terminal may take to answer.
+const backgroundQueryTimeout = 150 * time.Millisecond
+
+// Terminal is a terminal that can be queried with escape sequences. An
+// *os.File opened on /dev/tty satisfies it; so can a fake terminal.
+type Terminal interface {
+	io.ReadWriter
+	SetReadDeadline(t time.Time) error
+}
+
+// ConfigureBackground sets how themes with light and dark colors pick one.
+// auto asks the terminal, and only when such a theme is loaded.
+func ConfigureBackground(mode string) error {
+	switch mode {
+	case "", BackgroundAuto:
+		theme.SetBackground(detectBackground)
+		return nil
+	case BackgroundLight, BackgroundDark:
+		theme.SetBackground(func() string { return mode })
+		return nil
+	default:
+		return fmt.Errorf("invalid background %q (use auto, light, or dark)", mode)
+	}
+}
+
+// stdoutIsTerminal and openTTY are replaced in tests with fakes.
+var (
+	stdoutIsTerminal = func() bool { return term.IsTerminal(os.Stdout.Fd()) }
+	openTTY          = withTTY
+)
+
+// detectBackground asks the controlling terminal for its background color,
+// then falls back to COLORFGBG, then assumes dark.
+func detectBackground() string {
+	if stdoutIsTerminal() {
+		if bg, err := queryTTYBackground(); err == nil {
+			return backgroundOf(bg)
+		}
+	}
+	if dark, ok := ColorFGBGDark(os.Getenv("COLORFGBG")); ok && !dark {
+		return BackgroundLight
+	}
+	return BackgroundDark
+}
+
+// queryTTYBackground asks the controlling terminal for its background color.
+func queryTTYBackground() (bg color.RGBA, err error) {
+	err = openTTY(func(t Terminal) error {
+		bg, err = QueryBackground(t, backgroundQueryTimeout)
+		return err
+	})
+	return bg, err
+}
+
+// withTTY runs query on /dev/tty in raw mode, so replies are neither echoed
+// nor line-buffered.
+func withTTY(query func(t Terminal) error) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+
+	// Fd would switch the file to blocking mode and disable read deadlines,
+	// so go through SyscallConn.
+	conn, err := tty.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var state *term.State
+	var rawErr error
+	if err := conn.Control(func(fd uintptr) { state, rawErr = term.MakeRaw(fd) }); err != nil {
+		return err
+	}
+	if rawErr != nil {
+		return rawErr
+	}
+	defer conn.Control(func(fd uintptr) { term.Restore(fd, state) })
+
+	return query(tty)
+}
+
+var (
+	oscBackgroundReply = regexp.MustCompile(`\x1b\]11;rgba?:([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})`)
+	// Some terminals answer with a hex color instead: #rgb or #rrggbb.
+	oscBackgroundHexReply = regexp.MustCompile(`\x1b\]11;#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})\b`)
+	deviceAttrsReply      = regexp.MustCompile(`\x1b\[\?([0-9;]*)c`)
+)
+
+// QueryBackground sends an OSC 11 background color query followed by a
+// device attributes request. Every terminal answers the latter, so a
+// terminal without OSC 11 support is detected without waiting for timeout.
+func QueryBackground(t Terminal, timeout time.Duration) (color.RGBA, error) {
+	reply, err := query(t, "\x1b]11;?\x1b\\", timeout)
+	if err != nil {
+		return color.RGBA{}, err
+	}
+
+	var components [3]string
+	if match := oscBackgroundReply.FindSubmatch(reply); match != nil {
+		components = [3]string{string(match[1]), string(match[2]), string(match[3])}
+	} else if match := oscBackgroundHexReply.FindSubmatch(reply); match != nil {
+		hex := string(match[1])
+		n := len(hex) / 3
+		components = [3]string{hex[:n], hex[n : 2*n], hex[2*n:]}
+	} else {
+		return color.RGBA{}, errors.New("terminal did not report its background color")
+	}
+	return color.RGBA{
+		R: scaleComponent(components[0]),
+		G: scaleComponent(components[1]),
+		B: scaleComponent(components[2]),
+		A: 255,
+	}, nil
+}
+
+// query writes request followed by a device attributes request and returns
+// everything the terminal sends back up to the device attributes reply, or
+// until timeout.
+func query(t Terminal, request string, timeout time.Duration) ([]byte, error) {
+	if _, err := io.WriteString(t, request+"\x1b[c"); err != nil {
+		return nil, err
+	}
+	if err := t.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	var reply bytes.Buffer
+	buf := make([]byte, 256)
+	for !deviceAttrsReply.Match(reply.Bytes()) {
+		n, err := t.Read(buf)
+		reply.Write(buf[:n])
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				break
+			}
+			return nil, err
+		}
+	}
+	return reply.Bytes(), nil
+}
+
+// scaleComponent converts an X11 color component of 1-4 hex digits to 8 bits.
+func scaleComponent(hex string) uint8 {
+	value, _ := strconv.ParseUint(hex, 16, 16)
+	max := uint64(1)<<(4*len(hex)) - 1
+	return uint8(value * 255 / max)
+}
+
+// backgroundOf classifies a background color by its relative luminance.
+func backgroundOf(c color.RGBA) string {
+	luminance := 0.2126*float64(c.R) + 0.7152*float64(c.G) + 0.0722*float64(c.B)
+	if luminance > 127.5 {
+		return BackgroundLight
+	}
+	return BackgroundDark
+}
+
+// ColorFGBGDark interprets the COLORFGBG variable ("fg;bg" or
+// "fg;default;bg") set by rxvt, Konsole and others. ok is false when the
+// background is unknown.
+func ColorFGBGDark(value string) (dark, ok bool) {
+	fields := strings.Split(value, ";")
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if value == "" || err != nil || bg < 0 || bg > 15 {
+		return false, false
+	}
+	// ANSI 7 (white) and 9-15 (bright colors) are light backgrounds.
+	return !(bg == 7 || bg >= 9), true
+}
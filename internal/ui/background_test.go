@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"bytes"
+	"image/color"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTerminal answers every query with reply. With no reply it blocks until
+// the read deadline, like a terminal that ignores the query.
+type fakeTerminal struct {
+	reply    []byte
+	written  bytes.Buffer
+	deadline time.Time
+}
+
+func (f *fakeTerminal) Write(p []byte) (int, error) {
+	return f.written.Write(p)
+}
+
+func (f *fakeTerminal) Read(p []byte) (int, error) {
+	if len(f.reply) == 0 {
+		time.Sleep(time.Until(f.deadline))
+		return 0, os.ErrDeadlineExceeded
+	}
+	n := copy(p, f.reply)
+	f.reply = f.reply[n:]
+	return n, nil
+}
+
+func (f *fakeTerminal) SetReadDeadline(t time.Time) error {
+	f.deadline = t
+	return nil
+}
+
+const deviceAttrs = "\x1b[?62;22c"
+
+func TestQueryBackground(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{
+			name:  "rgb 16-bit",
+			reply: "\x1b]11;rgb:ffff/ffff/ffff\x1b\\" + deviceAttrs,
+			want:  color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		},
+		{
+			name:  "rgb 8-bit",
+			reply: "\x1b]11;rgb:1e/1e/2e\x07" + deviceAttrs,
+			want:  color.RGBA{R: 0x1e, G: 0x1e, B: 0x2e, A: 255},
+		},
+		{
+			name:  "short hex",
+			reply: "\x1b]11;#fff\x1b\\" + deviceAttrs,
+			want:  color.RGBA{R: 255, G: 255, B: 255, A: 255},
+		},
+		{
+			name:  "hex",
+			reply: "\x1b]11;#282a36\x1b\\" + deviceAttrs,
+			want:  color.RGBA{R: 0x28, G: 0x2a, B: 0x36, A: 255},
+		},
+		{
+			name:    "no OSC 11 support",
+			reply:   deviceAttrs,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := &fakeTerminal{reply: []byte(tt.reply)}
+			got, err := QueryBackground(term, time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("QueryBackground() = %v, want %v", got, tt.want)
+			}
+			if !strings.HasPrefix(term.written.String(), "\x1b]11;?") {
+				t.Errorf("query = %q, want an OSC 11 request", term.written.String())
+			}
+		})
+	}
+}
+
+func TestQueryBackgroundTimeout(t *testing.T) {
+	timeout := 50 * time.Millisecond
+	start := time.Now()
+	_, err := QueryBackground(&fakeTerminal{}, timeout)
+	if err == nil {
+		t.Fatal("expected an error when the terminal does not reply")
+	}
+	if elapsed := time.Since(start); elapsed > 10*timeout {
+		t.Errorf("QueryBackground took %s with a %s timeout", elapsed, timeout)
+	}
+}
+
+func TestDetectBackground(t *testing.T) {
+	tests := []struct {
+		name      string
+		tty       bool
+		reply     string
+		colorfgbg string
+		want      string
+		wantQuery bool
+	}{
+		{
+			name:      "light terminal",
+			tty:       true,
+			reply:     "\x1b]11;rgb:ffff/ffff/ffff\x1b\\" + deviceAttrs,
+			want:      BackgroundLight,
+			wantQuery: true,
+		},
+		{
+			name:      "dark terminal",
+			tty:       true,
+			reply:     "\x1b]11;#000\x1b\\" + deviceAttrs,
+			colorfgbg: "0;15",
+			want:      BackgroundDark,
+			wantQuery: true,
+		},
+		{
+			name:      "no reply falls back to COLORFGBG",
+			tty:       true,
+			colorfgbg: "0;15",
+			want:      BackgroundLight,
+			wantQuery: true,
+		},
+		{
+			name:      "not a tty",
+			reply:     "\x1b]11;rgb:ffff/ffff/ffff\x1b\\" + deviceAttrs,
+			colorfgbg: "15;0",
+			want:      BackgroundDark,
+		},
+		{
+			name: "not a tty without COLORFGBG",
+			want: BackgroundDark,
+		},
+	}
+	defer func(isTerminal func() bool, open func(func(Terminal) error) error) {
+		stdoutIsTerminal, openTTY = isTerminal, open
+	}(stdoutIsTerminal, openTTY)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := &fakeTerminal{reply: []byte(tt.reply)}
+			queried := false
+			stdoutIsTerminal = func() bool { return tt.tty }
+			openTTY = func(query func(Terminal) error) error {
+				queried = true
+				return query(term)
+			}
+			t.Setenv("COLORFGBG", tt.colorfgbg)
+
+			if got := detectBackground(); got != tt.want {
+				t.Errorf("detectBackground() = %q, want %q", got, tt.want)
+			}
+			if queried != tt.wantQuery {
+				t.Errorf("terminal queried = %v, want %v", queried, tt.wantQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Color modes accepted by --color and the color config option.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ConfigureColor sets the color profile used for all rendered output.
+//
+// auto leaves detection to lipgloss, which disables color when NO_COLOR is set
+// or stdout is not a terminal. always keeps color in pipes and files, using
+// the best profile the terminal advertises (at least 256 colors). never
+// renders plain text.
+func ConfigureColor(mode string) error {
+	switch mode {
+	case "", ColorAuto:
+		return nil
+	case ColorAlways:
+		profile := termenv.NewOutput(os.Stdout, termenv.WithUnsafe()).ColorProfile()
+		if profile == termenv.Ascii || profile == termenv.ANSI {
+			profile = termenv.ANSI256
+		}
+		lipgloss.SetColorProfile(profile)
+		return nil
+	case ColorNever:
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return nil
+	default:
+		return fmt.Errorf("invalid color mode %q (use auto, always, or never)", mode)
+	}
+}
@@ -363,13 +363,15 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 		}
 
 	case stepModules:
-		// Build module list from selections
-		m.config.Modules = []string{}
+		// Build module list from selections, keeping the options of
+		// modules that were already configured
+		mods := []config.ModuleConfig{}
 		for _, mod := range m.modules {
 			if m.selected[mod] {
-				m.config.Modules = append(m.config.Modules, mod)
+				mods = append(mods, m.config.Module(mod))
 			}
 		}
+		m.config.Modules = mods
 		m.step = stepPrivacy
 		m.cursor = 0
 
@@ -420,10 +422,12 @@ func detectPlugins(pluginDir string) []string {
 		}
 	}
 
-	externalDir := filepath.Join(pluginDir, "external")
-	externalEntries, err := os.ReadDir(externalDir)
-	if err == nil {
-		for _, entry := range externalEntries {
+	for _, dir := range []string{"external", "rpc"} {
+		dirEntries, err := os.ReadDir(filepath.Join(pluginDir, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range dirEntries {
 			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
 				continue
 			}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"github.com/howieduhzit/bubblefetch/internal/snapshot"
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
+)
+
+// RenderDiff builds a themed view of a snapshot comparison.
+func RenderDiff(cfg *config.Config, result snapshot.Result) string {
+	thm, err := theme.Load(cfg.Theme)
+	if err != nil {
+		thm, _ = theme.Load("default")
+	}
+	styles := thm.GetStyles()
+
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(thm.Colors.Accent))
+	added := lipgloss.NewStyle().Foreground(lipgloss.Color(thm.Colors.Value))
+	removed := lipgloss.NewStyle().Foreground(lipgloss.Color(thm.Colors.Accent)).Strikethrough(true)
+	arrow := styles.Separator.Render(" → ")
+
+	var b strings.Builder
+	b.WriteString(header.Render("󰦓 Snapshot Diff"))
+	b.WriteString("\n")
+	b.WriteString(styles.Label.Render("From"))
+	b.WriteString(styles.Separator.Render(": "))
+	b.WriteString(styles.Value.Render(describeSide(result.From)))
+	b.WriteString("\n")
+	b.WriteString(styles.Label.Render("To"))
+	b.WriteString(styles.Separator.Render(": "))
+	b.WriteString(styles.Value.Render(describeSide(result.To)))
+	b.WriteString("\n\n")
+
+	shown := 0
+	for _, change := range result.Changes {
+		oldValue := formatDiffValue(change, change.Old)
+		newValue := formatDiffValue(change, change.New)
+		// A size that changed by less than the precision shown, like disk
+		// usage between two runs, is not worth a row.
+		if change.Kind == snapshot.Changed && oldValue == newValue {
+			continue
+		}
+		shown++
+
+		switch change.Kind {
+		case snapshot.Added:
+			b.WriteString(added.Render("+ "))
+			b.WriteString(styles.Label.Render(change.Field))
+			b.WriteString(styles.Separator.Render(": "))
+			b.WriteString(added.Render(newValue))
+		case snapshot.Removed:
+			b.WriteString(removed.UnsetStrikethrough().Render("- "))
+			b.WriteString(styles.Label.Render(change.Field))
+			b.WriteString(styles.Separator.Render(": "))
+			b.WriteString(removed.Render(oldValue))
+		default:
+			b.WriteString(styles.Separator.Render("~ "))
+			b.WriteString(styles.Label.Render(change.Field))
+			b.WriteString(styles.Separator.Render(": "))
+			b.WriteString(removed.Render(oldValue))
+			b.WriteString(arrow)
+			b.WriteString(added.Render(newValue))
+		}
+		b.WriteString("\n")
+	}
+	if shown == 0 {
+		b.WriteString(styles.Value.Render("No changes"))
+	}
+
+	return styles.Border.Render(strings.TrimSuffix(b.String(), "\n"))
+}
+
+func describeSide(side snapshot.Side) string {
+	parts := []string{side.Label}
+	if !side.Timestamp.IsZero() {
+		parts = append(parts, side.Timestamp.Local().Format(time.DateTime))
+	}
+	if side.Host != "" {
+		parts = append(parts, side.Host)
+	}
+	return strings.Join(parts, " · ")
+}
+
+func formatDiffValue(change snapshot.Change, value string) string {
+	if !change.Bytes || value == "" {
+		return value
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return value
+	}
+	return sdk.FormatBytes(parsed)
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"github.com/howieduhzit/bubblefetch/internal/snapshot"
+)
+
+func TestRenderDiffSkipsEqualSizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []snapshot.Change
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "size below the shown precision",
+			changes: []snapshot.Change{
+				{Field: "Disk.Used", Kind: snapshot.Changed, Old: "19864223744", New: "19864227840", Bytes: true},
+				{Field: "Kernel", Kind: snapshot.Changed, Old: "6.8.1", New: "6.9.0"},
+			},
+			want:    []string{"~ Kernel: 6.8.1 → 6.9.0"},
+			notWant: []string{"Disk.Used", "No changes"},
+		},
+		{
+			name: "size that changed visibly",
+			changes: []snapshot.Change{
+				{Field: "Memory.Used", Kind: snapshot.Changed, Old: "4294967296", New: "8589934592", Bytes: true},
+			},
+			want: []string{"~ Memory.Used: 4.0 GiB → 8.0 GiB"},
+		},
+		{
+			name: "only sizes below the shown precision",
+			changes: []snapshot.Change{
+				{Field: "Disk.Used", Kind: snapshot.Changed, Old: "21474836480", New: "21474840576", Bytes: true},
+			},
+			want:    []string{"No changes"},
+			notWant: []string{"Disk.Used"},
+		},
+		{
+			name:    "no changes",
+			changes: []snapshot.Change{},
+			want:    []string{"No changes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ansi.Strip(RenderDiff(config.NewDefault(), snapshot.Result{Changes: tt.changes}))
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("RenderDiff() does not contain %q:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("RenderDiff() contains %q:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
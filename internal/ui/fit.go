@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/howieduhzit/bubblefetch/internal/ui/modules"
+)
+
+// minColumnWidth is the narrowest a module column may be squeezed to before
+// the layout gives up a column or the ASCII art instead.
+const minColumnWidth = 20
+
+// FitWidth fits every line of s into width columns, either truncating long
+// lines or wrapping them under the value, per overflow. Styles are kept on
+// both halves of a split line. A width of 0 or less leaves s unchanged.
+func FitWidth(s string, width int, overflow string) string {
+	if width <= 0 || s == "" {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	fitted := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if ansi.StringWidth(line) <= width {
+			fitted = append(fitted, line)
+			continue
+		}
+		if overflow == modules.OverflowWrap {
+			fitted = append(fitted, wrapLine(line, width)...)
+			continue
+		}
+		fitted = append(fitted, truncateLine(line, width))
+	}
+	return strings.Join(fitted, "\n")
+}
+
+func truncateLine(line string, width int) string {
+	if width <= 3 {
+		return ansi.Truncate(line, width, "")
+	}
+	return ansi.Truncate(line, width, "...")
+}
+
+// wrapLine breaks line at spaces where possible. Continuation lines are
+// indented to where the value starts, as in "Label: value".
+func wrapLine(line string, width int) []string {
+	indent := hangingIndent(ansi.Strip(line), width)
+	var lines []string
+	limit := width
+	for ansi.StringWidth(line) > limit {
+		first, _, _ := strings.Cut(ansi.Wrap(line, limit, " "), "\n")
+		n := ansi.StringWidth(first)
+		if n == 0 {
+			n = limit
+		}
+		rest := ansi.TruncateLeft(line, n, "")
+		if rest == line {
+			// A character wider than the limit; leave it to overflow.
+			break
+		}
+		lines = append(lines, continuation(ansi.Truncate(line, n, ""), len(lines), indent))
+		line = rest
+		for strings.HasPrefix(ansi.Strip(line), " ") {
+			line = ansi.TruncateLeft(line, 1, "")
+		}
+		limit = width - indent
+	}
+	if ansi.StringWidth(line) > 0 {
+		lines = append(lines, continuation(line, len(lines), indent))
+	}
+	return lines
+}
+
+func continuation(line string, index, indent int) string {
+	if index == 0 {
+		return line
+	}
+	return strings.Repeat(" ", indent) + line
+}
+
+// hangingIndent is the width of a line's "Label: " prefix, so wrapped values
+// line up. Lines without one, or with a prefix taking most of the width, get
+// a small fixed indent. At least one column is always left for the value.
+func hangingIndent(plain string, width int) int {
+	label, _, ok := strings.Cut(plain, ": ")
+	if !ok {
+		return 0
+	}
+	indent := ansi.StringWidth(label) + 2
+	if indent > width/2 {
+		indent = 2
+	}
+	return max(min(indent, width-1), 0)
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"github.com/howieduhzit/bubblefetch/internal/ui/modules"
+)
+
+func TestWrapLine(t *testing.T) {
+	tests := []struct {
+		line  string
+		width int
+		want  []string
+	}{
+		{line: "GPU: AMD Radeon", width: 1, want: []string{"G", "P", "U", ":", "A", "M", "D", "R", "a", "d", "e", "o", "n"}},
+		{line: "GPU: AMD", width: 2, want: []string{"GP", " U", " :", " A", " M", " D"}},
+		{line: "GPU: AMD", width: 3, want: []string{"GPU", "  :", "  A", "  M", "  D"}},
+		{line: "abcdef", width: 3, want: []string{"abc", "def"}},
+		{line: "GPU: AMD Radeon RX 6800", width: 16, want: []string{"GPU: AMD Radeon", "     RX 6800"}},
+		{line: "字字", width: 1, want: []string{"字字"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got := runWithin(t, func() []string { return wrapLine(tt.line, tt.width) })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrapLine(%q, %d) = %q, want %q", tt.line, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	const text = "OS: Arch Linux\nGPU: AMD Radeon"
+	for width := 0; width <= 3; width++ {
+		for _, overflow := range []string{modules.OverflowTruncate, modules.OverflowWrap} {
+			got := runWithin(t, func() string { return FitWidth(text, width, overflow) })
+			if width == 0 {
+				if got != text {
+					t.Errorf("FitWidth(width 0, %s) = %q, want it unchanged", overflow, got)
+				}
+				continue
+			}
+			for _, line := range strings.Split(got, "\n") {
+				if w := ansi.StringWidth(line); w > width {
+					t.Errorf("FitWidth(width %d, %s): line %q is %d wide", width, overflow, line, w)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderNarrow(t *testing.T) {
+	info := &collectors.SystemInfo{
+		OS:  "Arch Linux",
+		CPU: "AMD Ryzen 7 5800X (16) @ 3.8GHz",
+		GPU: []string{"AMD Radeon RX 6800 XT with a long name"},
+	}
+	for width := 1; width <= 12; width++ {
+		cfg := config.NewDefault()
+		cfg.Modules = config.Modules("os", "cpu", "gpu")
+		cfg.Layout.Width = width
+		got := runWithin(t, func() string { return RenderExport(cfg, info) })
+		for _, line := range strings.Split(got, "\n") {
+			if w := ansi.StringWidth(line); w > width {
+				t.Fatalf("width %d: line %q is %d wide", width, line, w)
+			}
+		}
+	}
+}
+
+// runWithin fails the test if f does not return within a second.
+func runWithin[T any](t *testing.T, f func() T) T {
+	t.Helper()
+	done := make(chan T, 1)
+	go func() { done <- f() }()
+	select {
+	case v := <-done:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("did not return; wrapping loops forever")
+		var zero T
+		return zero
+	}
+}
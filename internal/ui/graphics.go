@@ -0,0 +1,285 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"github.com/howieduhzit/bubblefetch/internal/ui/logo"
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+)
+
+// Graphics protocols accepted by the logo_protocol config option. blocks
+// draws image logos as half-block characters, which works everywhere.
+const (
+	GraphicsAuto   = "auto"
+	GraphicsKitty  = "kitty"
+	GraphicsSixel  = "sixel"
+	GraphicsITerm  = "iterm"
+	GraphicsBlocks = "blocks"
+)
+
+// Cell size assumed when the terminal does not report it.
+const (
+	defaultCellWidth  = 10
+	defaultCellHeight = 20
+)
+
+// logoMarker marks where an image logo goes in rendered output. It is an
+// APC sequence, so it takes no width while the layout is measured.
+const logoMarker = "\x1b_bubblefetch-logo\x1b\\"
+
+var (
+	graphicsOnce   sync.Once
+	graphicsDetect = func() graphicsSupport { return graphicsSupport{protocol: GraphicsBlocks} }
+	graphics       graphicsSupport
+)
+
+// graphicsSupport is the protocol used for image logos and the terminal's
+// cell size in pixels.
+type graphicsSupport struct {
+	protocol   string
+	cellWidth  int
+	cellHeight int
+}
+
+// ConfigureGraphics sets how later renders draw image logos. auto asks the
+// terminal, and only once an image logo is shown. Renders that are not
+// printed straight to the terminal, such as exports, should not configure
+// this; they keep the half-block rendering.
+func ConfigureGraphics(mode string) error {
+	switch mode {
+	case "", GraphicsAuto:
+		setGraphics(detectGraphics)
+		return nil
+	case GraphicsKitty, GraphicsSixel, GraphicsITerm, GraphicsBlocks:
+		setGraphics(func() graphicsSupport {
+			support := graphicsSupport{protocol: mode}
+			if mode == GraphicsSixel {
+				support.cellWidth, support.cellHeight = queryTTYCellSize()
+			}
+			return support
+		})
+		return nil
+	default:
+		return fmt.Errorf("invalid logo protocol %q (use auto, kitty, sixel, iterm, or blocks)", mode)
+	}
+}
+
+func setGraphics(detect func() graphicsSupport) {
+	graphicsOnce = sync.Once{}
+	graphicsDetect = detect
+}
+
+func currentGraphics() graphicsSupport {
+	graphicsOnce.Do(func() {
+		graphics = graphicsDetect()
+		if graphics.cellWidth <= 0 || graphics.cellHeight <= 0 {
+			graphics.cellWidth, graphics.cellHeight = defaultCellWidth, defaultCellHeight
+		}
+	})
+	return graphics
+}
+
+// detectGraphics picks a protocol from the environment of terminals known to
+// support one, then asks the terminal whether it supports sixel. Multiplexers
+// get half blocks, since they do not pass images through by default.
+func detectGraphics() graphicsSupport {
+	blocks := graphicsSupport{protocol: GraphicsBlocks}
+	if !term.IsTerminal(os.Stdout.Fd()) || os.Getenv("TMUX") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		return blocks
+	}
+
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "", os.Getenv("TERM") == "xterm-kitty",
+		os.Getenv("TERM") == "xterm-ghostty", os.Getenv("TERM_PROGRAM") == "ghostty":
+		return graphicsSupport{protocol: GraphicsKitty}
+	case os.Getenv("TERM_PROGRAM") == "iTerm.app", os.Getenv("TERM_PROGRAM") == "WezTerm",
+		os.Getenv("LC_TERMINAL") == "iTerm2":
+		return graphicsSupport{protocol: GraphicsITerm}
+	}
+
+	var attrs []int
+	var cellWidth, cellHeight int
+	err := withTTY(func(t Terminal) error {
+		reply, err := query(t, "\x1b[16t", backgroundQueryTimeout)
+		if err != nil {
+			return err
+		}
+		attrs = deviceAttributes(reply)
+		cellWidth, cellHeight = cellSize(reply)
+		return nil
+	})
+	if err == nil {
+		for _, attr := range attrs {
+			if attr == 4 {
+				return graphicsSupport{protocol: GraphicsSixel, cellWidth: cellWidth, cellHeight: cellHeight}
+			}
+		}
+	}
+	return blocks
+}
+
+// queryTTYCellSize asks the terminal for its cell size in pixels, returning
+// zeros when it doesn't say.
+func queryTTYCellSize() (width, height int) {
+	withTTY(func(t Terminal) error {
+		reply, err := query(t, "\x1b[16t", backgroundQueryTimeout)
+		width, height = cellSize(reply)
+		return err
+	})
+	return width, height
+}
+
+var cellSizeReply = regexp.MustCompile(`\x1b\[6;(\d+);(\d+)t`)
+
+// cellSize parses the reply to CSI 16 t, "CSI 6 ; height ; width t".
+func cellSize(reply []byte) (width, height int) {
+	match := cellSizeReply.FindSubmatch(reply)
+	if match == nil {
+		return 0, 0
+	}
+	height, _ = strconv.Atoi(string(match[1]))
+	width, _ = strconv.Atoi(string(match[2]))
+	return width, height
+}
+
+// deviceAttributes parses the parameters of a primary device attributes
+// reply. Attribute 4 means sixel graphics.
+func deviceAttributes(reply []byte) []int {
+	match := deviceAttrsReply.FindSubmatch(reply)
+	if match == nil {
+		return nil
+	}
+	var attrs []int
+	for _, field := range strings.Split(string(match[1]), ";") {
+		if n, err := strconv.Atoi(field); err == nil {
+			attrs = append(attrs, n)
+		}
+	}
+	return attrs
+}
+
+// logoArt is the logo part of the fetch view: text to lay out, and for
+// graphics protocols the escape sequence that draws the image over it along
+// with a half-block rendering of the same size.
+type logoArt struct {
+	text     string
+	image    string
+	fallback string
+}
+
+// renderLogo renders the configured image logo, or the theme's ASCII art
+// when there is none or it cannot be loaded.
+func renderLogo(cfg *config.Config, thm *theme.Theme, info *collectors.SystemInfo, styles theme.Styles) logoArt {
+	ascii := logoArt{}
+	if thm.ASCII != "" {
+		ascii.text = theme.RenderASCII(thm.ASCII, styles)
+	}
+
+	spec := thm.Logo
+	if cfg.Logo != "" {
+		spec = cfg.Logo
+	}
+	if spec == "" || spec == "none" {
+		return ascii
+	}
+	osName := info.OS
+	if osName == "" {
+		osName = theme.DetectOS()
+	}
+	img := loadLogo(spec, osName)
+	if img == nil {
+		return ascii
+	}
+
+	width := thm.Layout.ASCIIWidth
+	if width <= 0 {
+		width = 30
+	}
+	cols, rows := logo.Size(img, width)
+
+	support := currentGraphics()
+	var sequence string
+	var err error
+	switch support.protocol {
+	case GraphicsKitty:
+		sequence, err = logo.Kitty(img, cols, rows, support.cellWidth, support.cellHeight)
+	case GraphicsITerm:
+		sequence, err = logo.ITerm(img, cols, rows, support.cellWidth, support.cellHeight)
+	case GraphicsSixel:
+		sequence = logo.Sixel(img, cols, rows, support.cellWidth, support.cellHeight)
+	}
+	blocks := logo.HalfBlocks(img, cols, rows)
+	if sequence == "" || err != nil {
+		return logoArt{text: blocks}
+	}
+
+	// Reserve the space with blanks; the image is drawn over them once the
+	// output is laid out.
+	blank := strings.Repeat(" ", cols)
+	lines := make([]string, rows)
+	for i := range lines {
+		lines[i] = blank
+	}
+	lines[0] = logoMarker + blank
+	return logoArt{text: strings.Join(lines, "\n"), image: sequence, fallback: blocks}
+}
+
+var (
+	logoCacheMu sync.Mutex
+	logoCache   = make(map[string]image.Image)
+)
+
+// loadLogo loads a logo once per process, since animated exports render many
+// times, and warns once when it cannot be loaded. It returns nil when there
+// is no logo to show.
+func loadLogo(spec, osName string) image.Image {
+	logoCacheMu.Lock()
+	defer logoCacheMu.Unlock()
+	key := spec + "\x00" + osName
+	if img, ok := logoCache[key]; ok {
+		return img
+	}
+	img, err := logo.Load(spec, osName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: logo: %v\n", err)
+	}
+	logoCache[key] = img
+	return img
+}
+
+// drawLogo replaces the logo marker in laid-out output with the image. The
+// image is drawn after the text: the cursor is saved at the end of the
+// output, moved up to the marker, and restored.
+func drawLogo(output, image string) string {
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		index := strings.Index(line, logoMarker)
+		if index < 0 {
+			continue
+		}
+		column := ansi.StringWidth(line[:index])
+		lines[i] = line[:index] + line[index+len(logoMarker):]
+
+		var b strings.Builder
+		b.WriteString("\x1b7")
+		if up := len(lines) - 1 - i; up > 0 {
+			fmt.Fprintf(&b, "\x1b[%dA", up)
+		}
+		fmt.Fprintf(&b, "\x1b[%dG", column+1)
+		b.WriteString(image)
+		b.WriteString("\x1b8")
+		lines[len(lines)-1] += b.String()
+		break
+	}
+	return strings.Join(lines, "\n")
+}
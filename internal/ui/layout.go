@@ -0,0 +1,398 @@
+package ui
+
+import (
+	"os"
+	"os/user"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"github.com/howieduhzit/bubblefetch/internal/ui/modules"
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+)
+
+// columnGap separates module grid columns.
+const columnGap = "   "
+
+// layout is the effective layout of the fetch view: the theme's layout with
+// the config's overrides applied.
+type layout struct {
+	asciiPosition string
+	columns       int
+	sections      []section
+	header        []string
+	footer        []string
+}
+
+// section is a titled group of modules list entries.
+type section struct {
+	title   string
+	modules []config.ModuleConfig
+}
+
+func resolveLayout(cfg *config.Config, thm *theme.Theme) layout {
+	l := layout{
+		asciiPosition: thm.Layout.ASCIIPosition,
+		columns:       thm.Layout.Columns,
+		header:        thm.Layout.Header,
+		footer:        thm.Layout.Footer,
+	}
+	// Theme sections only name modules; their options come from the config.
+	for _, themeSection := range thm.Layout.Sections {
+		mods := make([]config.ModuleConfig, len(themeSection.Modules))
+		for i, name := range themeSection.Modules {
+			mods[i] = cfg.Module(name)
+		}
+		l.sections = append(l.sections, section{title: themeSection.Title, modules: mods})
+	}
+	if l.asciiPosition == "" {
+		l.asciiPosition = "left"
+	}
+	if !thm.Layout.ShowASCII {
+		l.asciiPosition = "hidden"
+	}
+
+	override := cfg.Layout
+	if override.ASCIIPosition != "" {
+		l.asciiPosition = override.ASCIIPosition
+	}
+	if override.Columns > 0 {
+		l.columns = override.Columns
+	}
+	if len(override.Sections) > 0 {
+		l.sections = make([]section, len(override.Sections))
+		for i, s := range override.Sections {
+			l.sections[i] = section{title: s.Title, modules: s.Modules}
+		}
+	}
+	if len(override.Header) > 0 {
+		l.header = override.Header
+	}
+	if len(override.Footer) > 0 {
+		l.footer = override.Footer
+	}
+
+	if len(l.sections) == 0 {
+		l.sections = []section{{modules: cfg.Modules}}
+	}
+	if l.columns < 1 {
+		l.columns = 1
+	}
+	return l
+}
+
+// renderedSection is a section whose modules have been rendered.
+type renderedSection struct {
+	title  string
+	blocks []renderedBlock
+}
+
+// renderedBlock is one module's output and how it fits a narrow terminal.
+type renderedBlock struct {
+	output   string
+	overflow string
+}
+
+// renderSections renders every module once, recording its cost. Modules
+// with no output are dropped, as are sections left empty. overflow overrides
+// the modules' own overflow policies.
+func renderSections(l layout, overflow map[string]string, info *collectors.SystemInfo, styles theme.Styles) []renderedSection {
+	var sections []renderedSection
+	for _, section := range l.sections {
+		rendered := renderedSection{title: section.title}
+		for _, moduleConfig := range section.modules {
+			module := modules.Factory(moduleConfig)
+			if module == nil {
+				continue
+			}
+			start := time.Now()
+			output := module.Render(info, styles)
+			renderDuration := time.Since(start)
+			if output == "" {
+				continue
+			}
+			if !collectors.HasModuleCost(info, module.Name()) {
+				collectors.AddModuleCost(info, module.Name(), renderDuration)
+			}
+			policy, ok := overflow[moduleConfig.Name]
+			if !ok {
+				policy = modules.OverflowOf(module)
+			}
+			rendered.blocks = append(rendered.blocks, renderedBlock{output: output, overflow: policy})
+		}
+		if len(rendered.blocks) > 0 {
+			sections = append(sections, rendered)
+		}
+	}
+	return sections
+}
+
+// arrangeModules lays out sections, header and footer as the info column.
+// A width above 0 fits each module into a grid column of that width, and
+// titles, header and footer into the whole column.
+func arrangeModules(sections []renderedSection, header, footer []string, columns, width int, styles theme.Styles) string {
+	columnWidth := 0
+	if width > 0 {
+		columnWidth = (width - (columns-1)*len(columnGap)) / columns
+	}
+	fitLines := func(lines []string) string {
+		return FitWidth(strings.Join(lines, "\n"), width, modules.OverflowTruncate)
+	}
+
+	var parts []string
+	if len(header) > 0 {
+		parts = append(parts, fitLines(header))
+	}
+	for i, section := range sections {
+		var lines []string
+		if section.title != "" {
+			if i > 0 || len(header) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, fitLines([]string{styles.Title.Render(section.title)}))
+		}
+		blocks := make([]string, len(section.blocks))
+		for j, block := range section.blocks {
+			blocks[j] = FitWidth(block.output, columnWidth, block.overflow)
+		}
+		lines = append(lines, grid(blocks, columns))
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	if len(footer) > 0 {
+		parts = append(parts, "\n"+fitLines(footer))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// grid arranges blocks in columns, filling each column top to bottom.
+func grid(blocks []string, columns int) string {
+	if columns <= 1 || len(blocks) < 2 {
+		return strings.Join(blocks, "\n")
+	}
+	if columns > len(blocks) {
+		columns = len(blocks)
+	}
+	perColumn := (len(blocks) + columns - 1) / columns
+
+	var cols []string
+	for start := 0; start < len(blocks); start += perColumn {
+		end := start + perColumn
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+		if len(cols) > 0 {
+			cols = append(cols, columnGap)
+		}
+		cols = append(cols, strings.Join(blocks[start:end], "\n"))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cols...)
+}
+
+// placeASCII combines the ASCII art and the info column.
+func placeASCII(ascii, info, position string, padding int) string {
+	if ascii == "" {
+		return info
+	}
+	gap := strings.Repeat(" ", padding)
+	switch position {
+	case "right":
+		return lipgloss.JoinHorizontal(lipgloss.Top, info, gap, ascii)
+	case "top":
+		return lipgloss.JoinVertical(lipgloss.Left, ascii, "", info)
+	case "hidden":
+		return info
+	default:
+		return lipgloss.JoinHorizontal(lipgloss.Top, ascii, gap, info)
+	}
+}
+
+// fallbacks returns progressively narrower variants of a layout: one
+// column, ASCII on top, then no ASCII. The last one never shows the art.
+func fallbacks(l layout) []layout {
+	variants := []layout{l}
+	if l.columns > 1 {
+		l.columns = 1
+		variants = append(variants, l)
+	}
+	if l.asciiPosition == "left" || l.asciiPosition == "right" {
+		l.asciiPosition = "top"
+		variants = append(variants, l)
+	}
+	if l.asciiPosition != "hidden" {
+		l.asciiPosition = "hidden"
+		variants = append(variants, l)
+	}
+	return variants
+}
+
+// fitAttempt is a layout to try and whether to fit module values into the
+// space it leaves rather than render them in full.
+type fitAttempt struct {
+	layout
+	fit bool
+}
+
+// fitAttempts orders the fallback layouts for a terminal of known width.
+// Layouts showing the ASCII art come first, each in full before any are
+// fitted, so values are only cut short when no layout fits as is; layouts
+// without the art follow the same way. hideASCII leaves only the latter.
+func fitAttempts(variants []layout, hideASCII bool) []fitAttempt {
+	var withArt, withoutArt []layout
+	for _, variant := range variants {
+		if variant.asciiPosition == "hidden" {
+			withoutArt = append(withoutArt, variant)
+		} else if !hideASCII {
+			withArt = append(withArt, variant)
+		}
+	}
+
+	var attempts []fitAttempt
+	for _, group := range [][]layout{withArt, withoutArt} {
+		for _, fit := range []bool{false, true} {
+			for _, variant := range group {
+				attempts = append(attempts, fitAttempt{layout: variant, fit: fit})
+			}
+		}
+	}
+	return attempts
+}
+
+var placeholderPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// expandLines renders header or footer lines. A line may be one of the
+// special placeholders {underline}, {colors} or {colors_bright}; otherwise
+// placeholders such as {title}, {user}, {host} or {os} are replaced inline.
+func expandLines(lines []string, cfg *config.Config, info *collectors.SystemInfo, styles theme.Styles) []string {
+	expanded := make([]string, 0, len(lines))
+	for _, line := range lines {
+		switch strings.TrimSpace(line) {
+		case "{underline}":
+			width := 0
+			if len(expanded) > 0 {
+				width = lipgloss.Width(expanded[len(expanded)-1])
+			}
+			expanded = append(expanded, styles.Separator.Render(strings.Repeat("-", width)))
+			continue
+		case "{colors}":
+			expanded = append(expanded, colorBlocks(0))
+			continue
+		case "{colors_bright}":
+			expanded = append(expanded, colorBlocks(8))
+			continue
+		}
+
+		var b strings.Builder
+		last := 0
+		for _, match := range placeholderPattern.FindAllStringSubmatchIndex(line, -1) {
+			b.WriteString(styles.Value.Render(line[last:match[0]]))
+			name := line[match[2]:match[3]]
+			if value, ok := placeholderValue(name, cfg, info, styles); ok {
+				b.WriteString(value)
+			} else {
+				b.WriteString(styles.Value.Render(line[match[0]:match[1]]))
+			}
+			last = match[1]
+		}
+		b.WriteString(styles.Value.Render(line[last:]))
+		expanded = append(expanded, b.String())
+	}
+	return expanded
+}
+
+func placeholderValue(name string, cfg *config.Config, info *collectors.SystemInfo, styles theme.Styles) (string, bool) {
+	var value string
+	switch name {
+	case "title":
+		title := styles.Label.Render(info.Hostname)
+		if userName := currentUser(cfg); userName != "" {
+			title = styles.Label.Render(userName) + styles.Value.Render("@") + title
+		}
+		return title, true
+	case "user":
+		value = currentUser(cfg)
+	case "host":
+		value = info.Hostname
+	case "os":
+		value = info.OS
+	case "kernel":
+		value = info.Kernel
+	case "uptime":
+		value = info.Uptime
+	case "shell":
+		value = info.Shell
+	case "terminal":
+		value = info.Terminal
+	case "cpu":
+		value = info.CPU
+	case "de":
+		value = info.DE
+	case "wm":
+		value = info.WM
+	default:
+		return "", false
+	}
+	return styles.Value.Render(value), true
+}
+
+// currentUser returns the user name for {user} and {title}: the local user,
+// or the user in the remote target when one is given.
+func currentUser(cfg *config.Config) string {
+	if cfg.Remote != "" {
+		target := cfg.Remote
+		if strings.Contains(target, "://") {
+			return ""
+		}
+		if name, _, ok := strings.Cut(target, "@"); ok {
+			return name
+		}
+		return cfg.SSH.User
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+// colorBlocks renders the eight terminal palette colors starting at first.
+func colorBlocks(first int) string {
+	var b strings.Builder
+	for i := first; i < first+8; i++ {
+		b.WriteString(lipgloss.NewStyle().Background(lipgloss.Color(strconv.Itoa(i))).Render("   "))
+	}
+	return b.String()
+}
+
+// TerminalWidth returns the width of the terminal on stdout, or 0 when
+// stdout is not a terminal.
+func TerminalWidth() int {
+	if !term.IsTerminal(os.Stdout.Fd()) {
+		return 0
+	}
+	if width, _, err := term.GetSize(os.Stdout.Fd()); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
+
+// TerminalHeight returns the height of the terminal on stdout, or 0 when
+// stdout is not a terminal.
+func TerminalHeight() int {
+	if !term.IsTerminal(os.Stdout.Fd()) {
+		return 0
+	}
+	if _, height, err := term.GetSize(os.Stdout.Fd()); err == nil && height > 0 {
+		return height
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+// Package logo loads image logos and draws them in the terminal, either with
+// a graphics protocol (kitty, sixel, iTerm2 inline images) or as colored
+// half-block text.
+package logo
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Auto selects the built-in logo of the detected distribution.
+const Auto = "auto"
+
+// svgSize is the pixel size SVG logos are rasterized at, enough for any
+// graphics protocol at a typical logo width.
+const svgSize = 512
+
+//go:embed logos/*.svg
+var builtinLogos embed.FS
+
+// Load reads a logo image: a PNG, JPEG, GIF or SVG file, or Auto for the
+// built-in logo of osName. It returns nil, nil when Auto has no built-in
+// logo for osName.
+func Load(spec, osName string) (image.Image, error) {
+	if spec == Auto {
+		name, ok := builtinName(osName)
+		if !ok {
+			return nil, nil
+		}
+		data, err := builtinLogos.ReadFile("logos/" + name + ".svg")
+		if err != nil {
+			return nil, err
+		}
+		return DecodeSVG(bytes.NewReader(data), svgSize)
+	}
+
+	path := expandHome(spec)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".svg") {
+		img, err := DecodeSVG(bytes.NewReader(data), svgSize)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return img, nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return img, nil
+}
+
+// builtinName returns the built-in logo for an OS name or distribution ID.
+func builtinName(osName string) (string, bool) {
+	osName = strings.ToLower(osName)
+	// Manjaro is checked before Arch, which its os-release also mentions.
+	for _, name := range []string{"manjaro", "arch", "ubuntu", "debian", "fedora"} {
+		if strings.Contains(osName, name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Size returns the cells img takes when drawn cols cells wide, keeping its
+// aspect ratio. Terminal cells are about twice as tall as they are wide.
+func Size(img image.Image, cols int) (int, int) {
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 {
+		return cols, 0
+	}
+	rows := int(math.Round(float64(cols) * float64(bounds.Dy()) / float64(bounds.Dx()) / 2))
+	return cols, max(rows, 1)
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
@@ -0,0 +1,140 @@
+package logo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/png"
+	"strings"
+)
+
+// kittyChunkSize is the largest payload kitty accepts per escape sequence.
+const kittyChunkSize = 4096
+
+// Kitty returns the kitty graphics protocol sequence that draws img over
+// cols x rows cells at the cursor, leaving the cursor where it was.
+func Kitty(img image.Image, cols, rows, cellWidth, cellHeight int) (string, error) {
+	data, err := encodePNG(resample(img, cols*cellWidth, rows*cellHeight))
+	if err != nil {
+		return "", err
+	}
+	payload := base64.StdEncoding.EncodeToString(data)
+
+	var b strings.Builder
+	for first := true; first || payload != ""; first = false {
+		chunk := payload
+		if len(chunk) > kittyChunkSize {
+			chunk = chunk[:kittyChunkSize]
+		}
+		payload = payload[len(chunk):]
+		more := 0
+		if payload != "" {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(&b, "\x1b_Ga=T,f=100,q=2,C=1,c=%d,r=%d,m=%d;%s\x1b\\", cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return b.String(), nil
+}
+
+// ITerm returns the iTerm2 inline image sequence that draws img over cols x
+// rows cells at the cursor. WezTerm and others understand it too.
+func ITerm(img image.Image, cols, rows, cellWidth, cellHeight int) (string, error) {
+	data, err := encodePNG(resample(img, cols*cellWidth, rows*cellHeight))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=0:%s\a",
+		len(data), cols, rows, base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// Sixel returns the sixel sequence that draws img over cols x rows cells of
+// cellWidth x cellHeight pixels. Colors are dithered to a 256-color palette;
+// transparent pixels keep the terminal background.
+func Sixel(img image.Image, cols, rows, cellWidth, cellHeight int) string {
+	pixels := resample(img, cols*cellWidth, rows*cellHeight)
+	bounds := pixels.Bounds()
+	indexed := image.NewPaletted(bounds, palette.Plan9)
+	draw.FloydSteinberg.Draw(indexed, bounds, pixels, image.Point{})
+
+	var b strings.Builder
+	// P2=1 leaves pixels that are not set unchanged, which keeps
+	// transparency.
+	fmt.Fprintf(&b, "\x1bP0;1;0q\"1;1;%d;%d", bounds.Dx(), bounds.Dy())
+	var used []uint8
+	var seen [256]bool
+	for i, index := range indexed.Pix {
+		if pixels.Pix[i*4+3] >= 128 && !seen[index] {
+			seen[index] = true
+			used = append(used, index)
+		}
+	}
+	for _, index := range used {
+		r, g, bl, _ := palette.Plan9[index].RGBA()
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", index, r*100/0xffff, g*100/0xffff, bl*100/0xffff)
+	}
+
+	for top := 0; top < bounds.Dy(); top += 6 {
+		for _, index := range used {
+			if !sixelBand(&b, pixels, indexed, top, index) {
+				continue
+			}
+			// Return to the start of the band for the next color.
+			b.WriteByte('$')
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\")
+	return b.String()
+}
+
+// sixelBand writes one color of the six-pixel band starting at row top,
+// run-length encoded. It reports whether the color appears in the band.
+func sixelBand(b *strings.Builder, pixels *image.NRGBA, indexed *image.Paletted, top int, index uint8) bool {
+	bounds := indexed.Bounds()
+	row := make([]byte, bounds.Dx())
+	found := false
+	for x := range row {
+		var bits byte
+		for dy := 0; dy < 6 && top+dy < bounds.Dy(); dy++ {
+			offset := indexed.PixOffset(x, top+dy)
+			if indexed.Pix[offset] == index && pixels.Pix[(top+dy)*pixels.Stride+x*4+3] >= 128 {
+				bits |= 1 << dy
+			}
+		}
+		row[x] = 63 + bits
+		found = found || bits != 0
+	}
+	if !found {
+		return false
+	}
+
+	fmt.Fprintf(b, "#%d", index)
+	for x := 0; x < len(row); {
+		run := 1
+		for x+run < len(row) && row[x+run] == row[x] {
+			run++
+		}
+		if run > 3 {
+			fmt.Fprintf(b, "!%d%c", run, row[x])
+		} else {
+			b.Write(bytes.Repeat([]byte{row[x]}, run))
+		}
+		x += run
+	}
+	return true
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,819 @@
+package logo
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+// DecodeSVG rasterizes an SVG image so its longer side is size pixels. It
+// draws the subset of SVG logos are usually made of: path, rect, circle,
+// ellipse, line, polyline and polygon elements in nested groups, with fill,
+// stroke, opacity and transform attributes or style properties. Gradients
+// are drawn in their first stop color; text, masks and filters are skipped.
+func DecodeSVG(r io.Reader, size int) (image.Image, error) {
+	root, err := parseSVGTree(r)
+	if err != nil {
+		return nil, err
+	}
+	if root.name != "svg" {
+		return nil, errors.New("not an SVG document")
+	}
+
+	width, height := svgLength(root.attrs["width"]), svgLength(root.attrs["height"])
+	minX, minY, vbWidth, vbHeight := 0.0, 0.0, width, height
+	if viewBox := svgNumbers(root.attrs["viewBox"]); len(viewBox) == 4 {
+		minX, minY, vbWidth, vbHeight = viewBox[0], viewBox[1], viewBox[2], viewBox[3]
+	}
+	// Also rejects NaN and infinite sizes, which parse as numbers.
+	if !(vbWidth > 0 && vbHeight > 0) || math.IsInf(vbWidth, 0) || math.IsInf(vbHeight, 0) {
+		return nil, errors.New("SVG has no size (set width and height or viewBox)")
+	}
+
+	scale := float64(size) / math.Max(vbWidth, vbHeight)
+	canvas := gg.NewContext(int(math.Ceil(vbWidth*scale)), int(math.Ceil(vbHeight*scale)))
+	s := &svgRenderer{ctx: canvas, gradients: make(map[string]paint)}
+	s.collectGradients(root)
+
+	base := affine{scale, 0, 0, scale, -minX * scale, -minY * scale}
+	s.draw(root, defaultSVGStyle(), base)
+	return canvas.Image(), nil
+}
+
+// svgNode is an element of a parsed SVG document.
+type svgNode struct {
+	name     string
+	attrs    map[string]string
+	children []*svgNode
+}
+
+func parseSVGTree(r io.Reader) (*svgNode, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	var stack []*svgNode
+	var root *svgNode
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &svgNode{name: t.Name.Local, attrs: make(map[string]string, len(t.Attr))}
+			for _, attr := range t.Attr {
+				node.attrs[attr.Name.Local] = attr.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("empty SVG document")
+	}
+	return root, nil
+}
+
+// paint is a fill or stroke: none, or a color.
+type paint struct {
+	none  bool
+	color color.NRGBA
+}
+
+type svgStyle struct {
+	fill          paint
+	stroke        paint
+	strokeWidth   float64
+	fillOpacity   float64
+	strokeOpacity float64
+	opacity       float64
+	evenOdd       bool
+	lineCap       gg.LineCap
+}
+
+func defaultSVGStyle() svgStyle {
+	return svgStyle{
+		fill:          paint{color: color.NRGBA{A: 255}},
+		stroke:        paint{none: true},
+		strokeWidth:   1,
+		fillOpacity:   1,
+		strokeOpacity: 1,
+		opacity:       1,
+		lineCap:       gg.LineCapButt,
+	}
+}
+
+type svgRenderer struct {
+	ctx       *gg.Context
+	gradients map[string]paint
+}
+
+// collectGradients records the first stop color of every gradient, so fills
+// that refer to one can use it as a solid color.
+func (s *svgRenderer) collectGradients(node *svgNode) {
+	if node.name == "linearGradient" || node.name == "radialGradient" {
+		for _, stop := range node.children {
+			if stop.name != "stop" {
+				continue
+			}
+			value := stop.attrs["stop-color"]
+			if v, ok := styleProperties(stop.attrs["style"])["stop-color"]; ok {
+				value = v
+			}
+			if p, ok := s.parsePaint(value); ok && node.attrs["id"] != "" {
+				s.gradients[node.attrs["id"]] = p
+			}
+			break
+		}
+	}
+	for _, child := range node.children {
+		s.collectGradients(child)
+	}
+	// A gradient without stops inherits them from the one it links to.
+	if id, href := node.attrs["id"], node.attrs["href"]; id != "" && strings.HasPrefix(href, "#") {
+		if _, ok := s.gradients[id]; !ok {
+			if p, ok := s.gradients[href[1:]]; ok {
+				s.gradients[id] = p
+			}
+		}
+	}
+}
+
+func (s *svgRenderer) draw(node *svgNode, style svgStyle, m affine) {
+	switch node.name {
+	case "defs", "clipPath", "mask", "pattern", "symbol", "marker", "linearGradient", "radialGradient", "style", "title", "desc", "metadata", "text":
+		return
+	}
+	if node.attrs["display"] == "none" || node.attrs["visibility"] == "hidden" {
+		return
+	}
+
+	style = s.applyStyle(style, node)
+	if transform := node.attrs["transform"]; transform != "" {
+		m = m.multiply(parseTransform(transform))
+	}
+
+	path := &svgPath{ctx: s.ctx, m: m}
+	switch node.name {
+	case "path":
+		path.data(node.attrs["d"])
+	case "rect":
+		path.rect(svgFloat(node.attrs["x"]), svgFloat(node.attrs["y"]),
+			svgFloat(node.attrs["width"]), svgFloat(node.attrs["height"]),
+			svgFloat(node.attrs["rx"]), svgFloat(node.attrs["ry"]))
+	case "circle":
+		r := svgFloat(node.attrs["r"])
+		path.ellipse(svgFloat(node.attrs["cx"]), svgFloat(node.attrs["cy"]), r, r)
+	case "ellipse":
+		path.ellipse(svgFloat(node.attrs["cx"]), svgFloat(node.attrs["cy"]),
+			svgFloat(node.attrs["rx"]), svgFloat(node.attrs["ry"]))
+	case "line":
+		path.moveTo(svgFloat(node.attrs["x1"]), svgFloat(node.attrs["y1"]))
+		path.lineTo(svgFloat(node.attrs["x2"]), svgFloat(node.attrs["y2"]))
+		style.fill.none = true
+	case "polyline", "polygon":
+		points := svgNumbers(node.attrs["points"])
+		for i := 0; i+1 < len(points); i += 2 {
+			if i == 0 {
+				path.moveTo(points[i], points[i+1])
+			} else {
+				path.lineTo(points[i], points[i+1])
+			}
+		}
+		if node.name == "polygon" {
+			path.close()
+		} else {
+			style.fill.none = true
+		}
+	default:
+		for _, child := range node.children {
+			s.draw(child, style, m)
+		}
+		return
+	}
+	s.paint(style, m)
+}
+
+// paint fills and strokes the current path, then clears it.
+func (s *svgRenderer) paint(style svgStyle, m affine) {
+	defer s.ctx.ClearPath()
+	if !style.fill.none {
+		s.setColor(style.fill.color, style.fillOpacity*style.opacity)
+		if style.evenOdd {
+			s.ctx.SetFillRule(gg.FillRuleEvenOdd)
+		} else {
+			s.ctx.SetFillRule(gg.FillRuleWinding)
+		}
+		s.ctx.FillPreserve()
+	}
+	if !style.stroke.none && style.strokeWidth > 0 {
+		s.setColor(style.stroke.color, style.strokeOpacity*style.opacity)
+		s.ctx.SetLineWidth(style.strokeWidth * m.scale())
+		s.ctx.SetLineCap(style.lineCap)
+		s.ctx.StrokePreserve()
+	}
+}
+
+func (s *svgRenderer) setColor(c color.NRGBA, opacity float64) {
+	s.ctx.SetRGBA(float64(c.R)/255, float64(c.G)/255, float64(c.B)/255, float64(c.A)/255*opacity)
+}
+
+// applyStyle returns the style of node: its parent's, overridden by
+// presentation attributes and then by the style attribute.
+func (s *svgRenderer) applyStyle(style svgStyle, node *svgNode) svgStyle {
+	properties := make(map[string]string)
+	for _, name := range []string{"fill", "stroke", "stroke-width", "fill-opacity", "stroke-opacity", "opacity", "fill-rule", "stroke-linecap"} {
+		if value, ok := node.attrs[name]; ok {
+			properties[name] = value
+		}
+	}
+	for name, value := range styleProperties(node.attrs["style"]) {
+		properties[name] = value
+	}
+
+	// Opacity is not inherited; it multiplies down the tree instead.
+	for name, value := range properties {
+		switch name {
+		case "fill":
+			if p, ok := s.parsePaint(value); ok {
+				style.fill = p
+			}
+		case "stroke":
+			if p, ok := s.parsePaint(value); ok {
+				style.stroke = p
+			}
+		case "stroke-width":
+			style.strokeWidth = svgLength(value)
+		case "fill-opacity":
+			style.fillOpacity = svgOpacity(value)
+		case "stroke-opacity":
+			style.strokeOpacity = svgOpacity(value)
+		case "opacity":
+			style.opacity *= svgOpacity(value)
+		case "fill-rule":
+			style.evenOdd = value == "evenodd"
+		case "stroke-linecap":
+			switch value {
+			case "round":
+				style.lineCap = gg.LineCapRound
+			case "square":
+				style.lineCap = gg.LineCapSquare
+			default:
+				style.lineCap = gg.LineCapButt
+			}
+		}
+	}
+	return style
+}
+
+func styleProperties(style string) map[string]string {
+	properties := make(map[string]string)
+	for _, declaration := range strings.Split(style, ";") {
+		if name, value, ok := strings.Cut(declaration, ":"); ok {
+			properties[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+	return properties
+}
+
+var svgColorNames = map[string]color.NRGBA{
+	"black":   {0, 0, 0, 255},
+	"white":   {255, 255, 255, 255},
+	"red":     {255, 0, 0, 255},
+	"green":   {0, 128, 0, 255},
+	"blue":    {0, 0, 255, 255},
+	"yellow":  {255, 255, 0, 255},
+	"orange":  {255, 165, 0, 255},
+	"purple":  {128, 0, 128, 255},
+	"gray":    {128, 128, 128, 255},
+	"grey":    {128, 128, 128, 255},
+	"silver":  {192, 192, 192, 255},
+	"navy":    {0, 0, 128, 255},
+	"teal":    {0, 128, 128, 255},
+	"maroon":  {128, 0, 0, 255},
+	"lime":    {0, 255, 0, 255},
+	"aqua":    {0, 255, 255, 255},
+	"cyan":    {0, 255, 255, 255},
+	"fuchsia": {255, 0, 255, 255},
+	"magenta": {255, 0, 255, 255},
+}
+
+// parsePaint parses a fill or stroke value. ok is false for values it does
+// not understand, which keep the inherited paint.
+func (s *svgRenderer) parsePaint(value string) (paint, bool) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "none" || value == "transparent":
+		return paint{none: true}, true
+	case value == "currentColor":
+		return paint{color: color.NRGBA{A: 255}}, true
+	case strings.HasPrefix(value, "url("):
+		id := strings.TrimSuffix(strings.TrimPrefix(value, "url(#"), ")")
+		id, _, _ = strings.Cut(id, ")")
+		p, ok := s.gradients[id]
+		return p, ok
+	case strings.HasPrefix(value, "#"):
+		hex := value[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		rgb, err := strconv.ParseUint(hex, 16, 32)
+		if len(hex) != 6 || err != nil {
+			return paint{}, false
+		}
+		return paint{color: color.NRGBA{uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb), 255}}, true
+	case strings.HasPrefix(value, "rgb"):
+		inner := value[strings.Index(value, "(")+1:]
+		inner = strings.TrimSuffix(strings.TrimSpace(inner), ")")
+		parts := strings.FieldsFunc(inner, func(r rune) bool { return r == ',' || r == ' ' || r == '/' })
+		if len(parts) < 3 {
+			return paint{}, false
+		}
+		c := color.NRGBA{A: 255}
+		channels := []*uint8{&c.R, &c.G, &c.B}
+		for i, channel := range channels {
+			part := parts[i]
+			n, _ := strconv.ParseFloat(strings.TrimSuffix(part, "%"), 64)
+			if strings.HasSuffix(part, "%") {
+				n = n * 255 / 100
+			}
+			*channel = uint8(math.Max(0, math.Min(255, n)))
+		}
+		return paint{color: c}, true
+	}
+	if c, ok := svgColorNames[strings.ToLower(value)]; ok {
+		return paint{color: c}, true
+	}
+	return paint{}, false
+}
+
+// affine is a 2D transform: x' = a*x + c*y + e, y' = b*x + d*y + f.
+type affine struct{ a, b, c, d, e, f float64 }
+
+var identity = affine{1, 0, 0, 1, 0, 0}
+
+// multiply returns the transform applying n, then m.
+func (m affine) multiply(n affine) affine {
+	return affine{
+		a: m.a*n.a + m.c*n.b,
+		b: m.b*n.a + m.d*n.b,
+		c: m.a*n.c + m.c*n.d,
+		d: m.b*n.c + m.d*n.d,
+		e: m.a*n.e + m.c*n.f + m.e,
+		f: m.b*n.e + m.d*n.f + m.f,
+	}
+}
+
+func (m affine) apply(x, y float64) (float64, float64) {
+	return m.a*x + m.c*y + m.e, m.b*x + m.d*y + m.f
+}
+
+// scale is the transform's average scale factor, used for stroke widths.
+func (m affine) scale() float64 {
+	return math.Sqrt(math.Abs(m.a*m.d - m.b*m.c))
+}
+
+// parseTransform parses a transform list such as "translate(10 20) scale(2)".
+func parseTransform(value string) affine {
+	m := identity
+	for {
+		open := strings.Index(value, "(")
+		end := strings.Index(value, ")")
+		if open < 0 || end < open {
+			return m
+		}
+		name := strings.TrimSpace(strings.Trim(value[:open], ", "))
+		args := svgNumbers(value[open+1 : end])
+		value = value[end+1:]
+
+		arg := func(i int, fallback float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return fallback
+		}
+		var t affine
+		switch name {
+		case "matrix":
+			if len(args) != 6 {
+				continue
+			}
+			t = affine{args[0], args[1], args[2], args[3], args[4], args[5]}
+		case "translate":
+			t = affine{1, 0, 0, 1, arg(0, 0), arg(1, 0)}
+		case "scale":
+			sx := arg(0, 1)
+			t = affine{sx, 0, 0, arg(1, sx), 0, 0}
+		case "rotate":
+			angle := arg(0, 0) * math.Pi / 180
+			cx, cy := arg(1, 0), arg(2, 0)
+			cos, sin := math.Cos(angle), math.Sin(angle)
+			t = affine{1, 0, 0, 1, cx, cy}.
+				multiply(affine{cos, sin, -sin, cos, 0, 0}).
+				multiply(affine{1, 0, 0, 1, -cx, -cy})
+		case "skewX":
+			t = affine{1, 0, math.Tan(arg(0, 0) * math.Pi / 180), 1, 0, 0}
+		case "skewY":
+			t = affine{1, math.Tan(arg(0, 0) * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			continue
+		}
+		m = m.multiply(t)
+	}
+}
+
+// svgPath builds a path on the canvas from user-space coordinates.
+type svgPath struct {
+	ctx          *gg.Context
+	m            affine
+	x, y         float64 // current point
+	startX       float64 // start of the current subpath
+	startY       float64
+	ctrlX, ctrlY float64 // last control point, for S and T
+	lastCmd      byte
+}
+
+func (p *svgPath) moveTo(x, y float64) {
+	p.ctx.MoveTo(p.m.apply(x, y))
+	p.x, p.y, p.startX, p.startY = x, y, x, y
+}
+
+func (p *svgPath) lineTo(x, y float64) {
+	p.ctx.LineTo(p.m.apply(x, y))
+	p.x, p.y = x, y
+}
+
+func (p *svgPath) cubicTo(x1, y1, x2, y2, x, y float64) {
+	ax, ay := p.m.apply(x1, y1)
+	bx, by := p.m.apply(x2, y2)
+	cx, cy := p.m.apply(x, y)
+	p.ctx.CubicTo(ax, ay, bx, by, cx, cy)
+	p.ctrlX, p.ctrlY = x2, y2
+	p.x, p.y = x, y
+}
+
+func (p *svgPath) quadTo(x1, y1, x, y float64) {
+	ax, ay := p.m.apply(x1, y1)
+	bx, by := p.m.apply(x, y)
+	p.ctx.QuadraticTo(ax, ay, bx, by)
+	p.ctrlX, p.ctrlY = x1, y1
+	p.x, p.y = x, y
+}
+
+func (p *svgPath) close() {
+	p.ctx.ClosePath()
+	p.x, p.y = p.startX, p.startY
+}
+
+// kappa places cubic control points that approximate a quarter ellipse.
+const kappa = 0.5522847498
+
+func (p *svgPath) ellipse(cx, cy, rx, ry float64) {
+	if rx <= 0 || ry <= 0 {
+		return
+	}
+	kx, ky := rx*kappa, ry*kappa
+	p.moveTo(cx+rx, cy)
+	p.cubicTo(cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+	p.cubicTo(cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+	p.cubicTo(cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+	p.cubicTo(cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+	p.close()
+}
+
+func (p *svgPath) rect(x, y, w, h, rx, ry float64) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	if rx == 0 {
+		rx = ry
+	}
+	if ry == 0 {
+		ry = rx
+	}
+	rx, ry = math.Min(rx, w/2), math.Min(ry, h/2)
+	if rx <= 0 {
+		p.moveTo(x, y)
+		p.lineTo(x+w, y)
+		p.lineTo(x+w, y+h)
+		p.lineTo(x, y+h)
+		p.close()
+		return
+	}
+	kx, ky := rx*kappa, ry*kappa
+	p.moveTo(x+rx, y)
+	p.lineTo(x+w-rx, y)
+	p.cubicTo(x+w-rx+kx, y, x+w, y+ry-ky, x+w, y+ry)
+	p.lineTo(x+w, y+h-ry)
+	p.cubicTo(x+w, y+h-ry+ky, x+w-rx+kx, y+h, x+w-rx, y+h)
+	p.lineTo(x+rx, y+h)
+	p.cubicTo(x+rx-kx, y+h, x, y+h-ry+ky, x, y+h-ry)
+	p.lineTo(x, y+ry)
+	p.cubicTo(x, y+ry-ky, x+rx-kx, y, x+rx, y)
+	p.close()
+}
+
+// data draws SVG path data, the d attribute of a path element.
+func (p *svgPath) data(d string) {
+	tokens := &pathTokens{s: d}
+	var cmd byte
+	for {
+		if c, ok := tokens.command(); ok {
+			cmd = c
+		} else if cmd == 0 || !tokens.more() {
+			return
+		}
+		if err := p.command(cmd, tokens); err != nil {
+			return
+		}
+		// Extra coordinates after a moveto are implicit linetos.
+		switch cmd {
+		case 'M':
+			cmd = 'L'
+		case 'm':
+			cmd = 'l'
+		case 'Z', 'z':
+			cmd = 0
+		}
+	}
+}
+
+func (p *svgPath) command(cmd byte, t *pathTokens) error {
+	relative := cmd >= 'a' && cmd <= 'z'
+	abs := func(x, y float64) (float64, float64) {
+		if relative {
+			return p.x + x, p.y + y
+		}
+		return x, y
+	}
+	n, err := t.numbers(map[byte]int{
+		'M': 2, 'L': 2, 'H': 1, 'V': 1, 'C': 6, 'S': 4, 'Q': 4, 'T': 2, 'A': 7, 'Z': 0,
+	}[upper(cmd)], upper(cmd) == 'A')
+	if err != nil {
+		return err
+	}
+
+	// Smooth curves reflect the previous control point only after a curve
+	// of the same kind.
+	reflect := func(kinds string) (float64, float64) {
+		if strings.IndexByte(kinds, upper(p.lastCmd)) >= 0 {
+			return 2*p.x - p.ctrlX, 2*p.y - p.ctrlY
+		}
+		return p.x, p.y
+	}
+
+	switch upper(cmd) {
+	case 'M':
+		p.moveTo(abs(n[0], n[1]))
+	case 'L':
+		p.lineTo(abs(n[0], n[1]))
+	case 'H':
+		x := n[0]
+		if relative {
+			x += p.x
+		}
+		p.lineTo(x, p.y)
+	case 'V':
+		y := n[0]
+		if relative {
+			y += p.y
+		}
+		p.lineTo(p.x, y)
+	case 'C':
+		x1, y1 := abs(n[0], n[1])
+		x2, y2 := abs(n[2], n[3])
+		x, y := abs(n[4], n[5])
+		p.cubicTo(x1, y1, x2, y2, x, y)
+	case 'S':
+		x1, y1 := reflect("CS")
+		x2, y2 := abs(n[0], n[1])
+		x, y := abs(n[2], n[3])
+		p.cubicTo(x1, y1, x2, y2, x, y)
+	case 'Q':
+		x1, y1 := abs(n[0], n[1])
+		x, y := abs(n[2], n[3])
+		p.quadTo(x1, y1, x, y)
+	case 'T':
+		x1, y1 := reflect("QT")
+		x, y := abs(n[0], n[1])
+		p.quadTo(x1, y1, x, y)
+	case 'A':
+		x, y := abs(n[5], n[6])
+		p.arcTo(n[0], n[1], n[2], n[3] != 0, n[4] != 0, x, y)
+	case 'Z':
+		p.close()
+	}
+	p.lastCmd = cmd
+	return nil
+}
+
+// arcTo draws an elliptical arc as cubic curves, converting the endpoint
+// parameters to center form as described in the SVG specification.
+func (p *svgPath) arcTo(rx, ry, rotation float64, largeArc, sweep bool, x, y float64) {
+	x0, y0 := p.x, p.y
+	if x0 == x && y0 == y {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		p.lineTo(x, y)
+		return
+	}
+
+	phi := rotation * math.Pi / 180
+	cos, sin := math.Cos(phi), math.Sin(phi)
+	dx, dy := (x0-x)/2, (y0-y)/2
+	x1 := cos*dx + sin*dy
+	y1 := -sin*dx + cos*dy
+
+	// Scale up radii that are too small to reach the end point.
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	factor := math.Sqrt(math.Max(0, num/den))
+	if largeArc == sweep {
+		factor = -factor
+	}
+	cx1 := factor * rx * y1 / ry
+	cy1 := -factor * ry * x1 / rx
+	cx := cos*cx1 - sin*cy1 + (x0+x)/2
+	cy := sin*cx1 + cos*cy1 + (y0+y)/2
+
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta := angle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	segments := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(segments)
+	k := 4.0 / 3 * math.Tan(step/4)
+	point := func(t float64) (float64, float64) {
+		ex, ey := rx*math.Cos(t), ry*math.Sin(t)
+		return cos*ex - sin*ey + cx, sin*ex + cos*ey + cy
+	}
+	derivative := func(t float64) (float64, float64) {
+		ex, ey := -rx*math.Sin(t), ry*math.Cos(t)
+		return cos*ex - sin*ey, sin*ex + cos*ey
+	}
+	for i := 0; i < segments; i++ {
+		t1, t2 := theta+float64(i)*step, theta+float64(i+1)*step
+		px1, py1 := point(t1)
+		px2, py2 := point(t2)
+		dx1, dy1 := derivative(t1)
+		dx2, dy2 := derivative(t2)
+		p.cubicTo(px1+k*dx1, py1+k*dy1, px2-k*dx2, py2-k*dy2, px2, py2)
+	}
+	p.x, p.y = x, y
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// pathTokens reads commands and numbers from path data.
+type pathTokens struct {
+	s   string
+	pos int
+}
+
+func (t *pathTokens) skipSeparators() {
+	for t.pos < len(t.s) && strings.IndexByte(" \t\r\n,", t.s[t.pos]) >= 0 {
+		t.pos++
+	}
+}
+
+func (t *pathTokens) more() bool {
+	t.skipSeparators()
+	return t.pos < len(t.s)
+}
+
+func (t *pathTokens) command() (byte, bool) {
+	t.skipSeparators()
+	if t.pos < len(t.s) && strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", t.s[t.pos]) >= 0 {
+		t.pos++
+		return t.s[t.pos-1], true
+	}
+	return 0, false
+}
+
+// numbers reads count numbers. In arcs, the two flags (the fourth and fifth
+// numbers) may be written without separators, as in "a1 1 0 00 1 1".
+func (t *pathTokens) numbers(count int, arc bool) ([]float64, error) {
+	values := make([]float64, count)
+	for i := range values {
+		t.skipSeparators()
+		if arc && (i == 3 || i == 4) && t.pos < len(t.s) && (t.s[t.pos] == '0' || t.s[t.pos] == '1') {
+			values[i] = float64(t.s[t.pos] - '0')
+			t.pos++
+			continue
+		}
+		value, err := t.number()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (t *pathTokens) number() (float64, error) {
+	start := t.pos
+	i := t.pos
+	if i < len(t.s) && (t.s[i] == '+' || t.s[i] == '-') {
+		i++
+	}
+	digits, dot := false, false
+	for i < len(t.s) {
+		c := t.s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = true
+		case c == '.' && !dot:
+			dot = true
+		case (c == 'e' || c == 'E') && digits:
+			i++
+			if i < len(t.s) && (t.s[i] == '+' || t.s[i] == '-') {
+				i++
+			}
+			for i < len(t.s) && t.s[i] >= '0' && t.s[i] <= '9' {
+				i++
+			}
+			t.pos = i
+			return strconv.ParseFloat(t.s[start:i], 64)
+		default:
+			goto done
+		}
+		i++
+	}
+done:
+	if !digits {
+		return 0, fmt.Errorf("bad path data at offset %d", start)
+	}
+	t.pos = i
+	return strconv.ParseFloat(t.s[start:i], 64)
+}
+
+// svgNumbers parses a list of numbers separated by spaces or commas.
+func svgNumbers(value string) []float64 {
+	var numbers []float64
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\n' }) {
+		if n, err := strconv.ParseFloat(field, 64); err == nil {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
+// svgLength parses a length, ignoring px units.
+func svgLength(value string) float64 {
+	return svgFloat(strings.TrimSuffix(strings.TrimSpace(value), "px"))
+}
+
+func svgFloat(value string) float64 {
+	n, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	return n
+}
+
+func svgOpacity(value string) float64 {
+	value = strings.TrimSpace(value)
+	n, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil {
+		return 1
+	}
+	if strings.HasSuffix(value, "%") {
+		n /= 100
+	}
+	return math.Max(0, math.Min(1, n))
+}
@@ -0,0 +1,236 @@
+package logo
+
+import (
+	"image"
+	"image/color"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+// coverage is the share of img's pixels that are not fully transparent.
+func coverage(img image.Image) float64 {
+	bounds := img.Bounds()
+	painted := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0 {
+				painted++
+			}
+		}
+	}
+	return float64(painted) / float64(bounds.Dx()*bounds.Dy())
+}
+
+func nrgba(img image.Image, x, y int) color.NRGBA {
+	return color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+}
+
+func TestDecodeBuiltinLogos(t *testing.T) {
+	names, err := fs.Glob(builtinLogos, "logos/*.svg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Fatal("no built-in logos")
+	}
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			data, err := builtinLogos.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			img, err := DecodeSVG(strings.NewReader(string(data)), 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bounds := img.Bounds(); max(bounds.Dx(), bounds.Dy()) != 64 {
+				t.Errorf("size = %v, want a longer side of 64", bounds.Size())
+			}
+			if c := coverage(img); c < 0.05 || c > 0.95 {
+				t.Errorf("%.0f%% of the image is painted; the logo was not drawn", c*100)
+			}
+		})
+	}
+}
+
+func TestLoadAuto(t *testing.T) {
+	for _, osName := range []string{"Arch Linux", "Manjaro Linux", "Ubuntu 24.04 LTS", "Debian GNU/Linux 12", "Fedora Linux 40"} {
+		img, err := Load(Auto, osName)
+		if err != nil || img == nil {
+			t.Errorf("Load(auto, %q) = %v, %v", osName, img, err)
+		}
+	}
+	if img, err := Load(Auto, "Plan 9"); img != nil || err != nil {
+		t.Errorf("Load(auto, Plan 9) = %v, %v, want nil, nil", img, err)
+	}
+}
+
+func TestDecodeSVGShapes(t *testing.T) {
+	red := color.NRGBA{255, 0, 0, 255}
+	blue := color.NRGBA{0, 0, 255, 255}
+	tests := []struct {
+		name  string
+		svg   string
+		at    image.Point
+		want  color.NRGBA
+		empty image.Point // a point that stays transparent
+	}{
+		{
+			name:  "rect",
+			svg:   `<svg viewBox="0 0 10 10"><rect x="5" y="0" width="5" height="10" fill="red"/></svg>`,
+			at:    image.Pt(8, 5),
+			want:  red,
+			empty: image.Pt(2, 5),
+		},
+		{
+			name:  "circle in a translated group",
+			svg:   `<svg viewBox="0 0 10 10"><g transform="translate(5 5)"><circle r="2" fill="#00f"/></g></svg>`,
+			at:    image.Pt(5, 5),
+			want:  blue,
+			empty: image.Pt(1, 1),
+		},
+		{
+			name:  "style overrides attributes",
+			svg:   `<svg viewBox="0 0 10 10"><rect width="10" height="10" fill="blue" style="fill: rgb(255, 0, 0)"/></svg>`,
+			at:    image.Pt(5, 5),
+			want:  red,
+			empty: image.Pt(-1, -1),
+		},
+		{
+			name:  "gradient as its first stop",
+			svg:   `<svg viewBox="0 0 10 10"><defs><linearGradient id="g"><stop stop-color="#ff0000"/><stop stop-color="#0000ff"/></linearGradient></defs><rect width="10" height="5" fill="url(#g)"/></svg>`,
+			at:    image.Pt(5, 2),
+			want:  red,
+			empty: image.Pt(5, 8),
+		},
+		{
+			name:  "path with arcs",
+			svg:   `<svg viewBox="0 0 10 10"><path fill="red" d="M1 5a4 4 0 1 0 8 0a4 4 0 1 0-8 0z"/></svg>`,
+			at:    image.Pt(5, 5),
+			want:  red,
+			empty: image.Pt(0, 0),
+		},
+		{
+			name:  "compact path data",
+			svg:   `<svg viewBox="0 0 10 10"><path fill="red" d="M0,0H10V5H0Z"/><path fill="blue" d="m0 10 10-0-.0-5l-10 0z"/></svg>`,
+			at:    image.Pt(5, 8),
+			want:  blue,
+			empty: image.Pt(-1, -1),
+		},
+		{
+			name:  "hidden and skipped elements",
+			svg:   `<svg viewBox="0 0 10 10"><rect width="10" height="10" fill="red" display="none"/><text x="5" y="5">x</text><mask><rect width="10" height="10"/></mask></svg>`,
+			at:    image.Pt(-1, -1),
+			empty: image.Pt(5, 5),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := DecodeSVG(strings.NewReader(tt.svg), 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.at.X >= 0 {
+				if got := nrgba(img, tt.at.X, tt.at.Y); got != tt.want {
+					t.Errorf("pixel %v = %v, want %v", tt.at, got, tt.want)
+				}
+			}
+			if tt.empty.X >= 0 {
+				if got := nrgba(img, tt.empty.X, tt.empty.Y); got.A != 0 {
+					t.Errorf("pixel %v = %v, want transparent", tt.empty, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeSVGSize(t *testing.T) {
+	tests := []struct {
+		svg  string
+		want image.Point
+	}{
+		{`<svg width="200" height="100"/>`, image.Pt(64, 32)},
+		{`<svg width="200px" height="400px"/>`, image.Pt(32, 64)},
+		{`<svg width="10" height="10" viewBox="0 0 50 100"/>`, image.Pt(32, 64)},
+	}
+	for _, tt := range tests {
+		img, err := DecodeSVG(strings.NewReader(tt.svg), 64)
+		if err != nil {
+			t.Errorf("%s: %v", tt.svg, err)
+			continue
+		}
+		if got := img.Bounds().Size(); got != tt.want {
+			t.Errorf("%s: size = %v, want %v", tt.svg, got, tt.want)
+		}
+	}
+}
+
+// Malformed input must be reported or drawn as far as it goes, never panic.
+func TestDecodeSVGMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		svg     string
+		wantErr string
+	}{
+		{name: "empty", svg: "", wantErr: "empty SVG document"},
+		{name: "not SVG", svg: `<html><body/></html>`, wantErr: "not an SVG document"},
+		{name: "no size", svg: `<svg><rect width="1" height="1"/></svg>`, wantErr: "SVG has no size"},
+		{name: "negative viewBox", svg: `<svg viewBox="0 0 -10 10"/>`, wantErr: "SVG has no size"},
+		{name: "NaN viewBox", svg: `<svg viewBox="0 0 NaN 10"/>`, wantErr: "SVG has no size"},
+		{name: "infinite width", svg: `<svg width="Inf" height="10"/>`, wantErr: "SVG has no size"},
+		{name: "truncated", svg: `<svg viewBox="0 0 10 10"><path d="M0 0 L10`, wantErr: "unexpected EOF"},
+		{name: "bad path data", svg: `<svg viewBox="0 0 10 10"><path d="M0 0 L x y C 1 Z"/><path d="Q"/><path d="A1 1 0 2 2 5 5"/></svg>`},
+		{name: "huge numbers", svg: `<svg viewBox="0 0 10 10"><path d="M0 0L1e999 5Z"/><circle r="1e308" stroke="red" stroke-width="1e308"/></svg>`},
+		{name: "degenerate arcs", svg: `<svg viewBox="0 0 10 10"><path d="M5 5A0 0 0 0 1 6 6A1 1 0 0 1 6 6A1e-300 1e-300 0 1 1 1 1"/></svg>`},
+		{name: "bad transforms", svg: `<svg viewBox="0 0 10 10"><g transform="matrix(1 2) rotate( scale(0) translate(1"><rect width="5" height="5"/></g><rect transform=")(" width="5" height="5"/></svg>`},
+		{name: "bad colors", svg: `<svg viewBox="0 0 10 10"><rect width="5" height="5" fill="#12" stroke="rgb(1)" style="fill;:;opacity:x"/><rect width="5" height="5" fill="url(#missing" opacity="-3"/></svg>`},
+		{name: "bad polygon", svg: `<svg viewBox="0 0 10 10"><polygon points="1 2 3"/><polyline points=""/><line x1="a"/></svg>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := DecodeSVG(strings.NewReader(tt.svg), 32)
+			if tt.wantErr == "" {
+				if err != nil || img == nil {
+					t.Fatalf("DecodeSVG() = %v, %v, want an image", img, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("DecodeSVG() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePathNumbers(t *testing.T) {
+	tests := []struct {
+		data string
+		want []float64
+	}{
+		{"1.5.5-2e1+3", []float64{1.5, 0.5, -20, 3}},
+		{"  1 , 2\n3", []float64{1, 2, 3}},
+		{"1e-3E2", []float64{1e-3}},
+	}
+	for _, tt := range tests {
+		tokens := &pathTokens{s: tt.data}
+		var got []float64
+		for tokens.more() {
+			n, err := tokens.number()
+			if err != nil {
+				break
+			}
+			got = append(got, n)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("numbers in %q = %v, want %v", tt.data, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("numbers in %q = %v, want %v", tt.data, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package logo
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	xdraw "golang.org/x/image/draw"
+)
+
+// resample scales img to width x height pixels.
+func resample(img image.Image, width, height int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), xdraw.Src, nil)
+	return dst
+}
+
+// HalfBlocks renders img as cols x rows cells of upper and lower half block
+// characters, so each cell shows two pixels. Transparent pixels are left
+// blank. Colors are reduced to the active color profile by lipgloss.
+func HalfBlocks(img image.Image, cols, rows int) string {
+	pixels := resample(img, cols, rows*2)
+
+	var b strings.Builder
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			top, bottom := pixels.NRGBAAt(x, 2*y), pixels.NRGBAAt(x, 2*y+1)
+			switch {
+			case opaque(top) && opaque(bottom):
+				b.WriteString(lipgloss.NewStyle().Foreground(hexColor(top)).Background(hexColor(bottom)).Render("▀"))
+			case opaque(top):
+				b.WriteString(lipgloss.NewStyle().Foreground(hexColor(top)).Render("▀"))
+			case opaque(bottom):
+				b.WriteString(lipgloss.NewStyle().Foreground(hexColor(bottom)).Render("▄"))
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		if y < rows-1 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// ASCII renders img as width x height characters, picking denser
+// characters for brighter pixels.
+func ASCII(img image.Image, width, height int) string {
+	bounds := img.Bounds()
+	asciiChars := []rune(" .:-=+*#%@")
+
+	var result strings.Builder
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			// Map ASCII coordinates to image coordinates
+			imgX := bounds.Min.X + (x * bounds.Dx() / width)
+			imgY := bounds.Min.Y + (y * bounds.Dy() / height)
+
+			// Get pixel color
+			r, g, b, _ := img.At(imgX, imgY).RGBA()
+
+			// Convert to grayscale (0-255)
+			gray := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 256.0
+
+			// Map grayscale to ASCII character
+			charIndex := int(gray / 255.0 * float64(len(asciiChars)-1))
+			if charIndex < 0 {
+				charIndex = 0
+			}
+			if charIndex >= len(asciiChars) {
+				charIndex = len(asciiChars) - 1
+			}
+
+			result.WriteRune(asciiChars[charIndex])
+		}
+		if y < height-1 {
+			result.WriteRune('\n')
+		}
+	}
+
+	return result.String()
+}
+
+func opaque(c color.NRGBA) bool {
+	return c.A >= 128
+}
+
+func hexColor(c color.NRGBA) lipgloss.Color {
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B))
+}
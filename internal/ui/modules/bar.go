@@ -0,0 +1,53 @@
+package modules
+
+import (
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
+)
+
+// The bar helpers live in the plugin SDK, so plugins draw the same bars as
+// the built-in modules.
+
+// Glyph sets for usage bars.
+const (
+	BarBlocks  = sdk.BarBlocks
+	BarBraille = sdk.BarBraille
+	BarASCII   = sdk.BarASCII
+)
+
+// Thresholds are the percentages at which a usage turns the theme's warning
+// and critical colors.
+type Thresholds = sdk.Thresholds
+
+// Default thresholds for usage and for charge levels.
+var (
+	UsageThresholds  = sdk.UsageThresholds
+	ChargeThresholds = sdk.ChargeThresholds
+)
+
+// BarOptions controls how a bar is drawn.
+type BarOptions = sdk.BarOptions
+
+// Bar draws percent as a bar colored by the thresholds, with the unfilled
+// part faint.
+func Bar(percent float64, opts BarOptions, styles theme.Styles) string {
+	return sdk.Bar(percent, opts, sdk.Styles{
+		Good:     styles.Good,
+		Warning:  styles.Warning,
+		Critical: styles.Critical,
+	})
+}
+
+// barOptions converts a module's bar config. Thresholds it leaves unset
+// come from thresholds.
+func barOptions(bar *config.BarConfig, thresholds Thresholds) BarOptions {
+	opts := BarOptions{Width: bar.Width, Glyphs: bar.Glyphs, Thresholds: thresholds}
+	if bar.Warning != 0 {
+		opts.Thresholds.Warning = bar.Warning
+	}
+	if bar.Critical != 0 {
+		opts.Thresholds.Critical = bar.Critical
+	}
+	return opts
+}
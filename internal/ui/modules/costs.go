@@ -11,7 +11,7 @@ import (
 	"github.com/mattn/go-runewidth"
 )
 
-type ModuleCostModule struct{}
+type ModuleCostModule struct{ options }
 
 func (m *ModuleCostModule) Name() string { return "costs" }
 
@@ -36,11 +36,15 @@ func (m *ModuleCostModule) Render(info *collectors.SystemInfo, styles theme.Styl
 		}
 	}
 
+	label, icon := m.labelIcon("Module Cost", "󰓅")
+	if icon != "" {
+		label = " " + label
+	}
 	var b strings.Builder
 	b.WriteString(lipgloss.JoinHorizontal(
 		lipgloss.Left,
-		styles.Separator.Render("󰓅"),
-		styles.Label.Render(" Module Cost"),
+		styles.Separator.Render(icon),
+		styles.Label.Render(label),
 	))
 
 	for _, cost := range costs {
@@ -0,0 +1,269 @@
+package modules
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
+	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
+	"github.com/mattn/go-runewidth"
+)
+
+// CustomModule shows the output of a template from the config, executed on
+// the collected SystemInfo.
+type CustomModule struct {
+	options
+	name string
+	tmpl *template.Template
+}
+
+// newCustomModule parses a custom module's template. Colors are looked up in
+// the styles the template is executed with.
+func newCustomModule(cfg config.ModuleConfig) (*CustomModule, error) {
+	m := &CustomModule{options: newOptions(cfg), name: cfg.Name}
+	tmpl, err := template.New(cfg.Name).
+		Option("missingkey=error").
+		Funcs(m.funcs(theme.PlainStyles())).
+		Parse(cfg.Template)
+	if err != nil {
+		return nil, err
+	}
+	m.tmpl = tmpl
+	return m, nil
+}
+
+// ValidateCustom checks the templates of the config's custom modules by
+// parsing them and running them on sample system information, so that
+// unknown functions and fields are reported before anything is shown. An
+// index past the end of a sample list yields an empty element instead of an
+// error, since the real system may have more GPUs or interfaces than the
+// sample, and the rest of the template is still checked.
+func ValidateCustom(cfg *config.Config) error {
+	check := func(field string, mods []config.ModuleConfig) error {
+		for i, mod := range mods {
+			if mod.Type != config.ModuleTypeCustom {
+				continue
+			}
+			m, err := newCustomModule(mod)
+			if err == nil {
+				funcs := m.funcs(theme.PlainStyles())
+				funcs["index"] = sampleIndex
+				_, err = m.executeFuncs(sampleInfo, funcs)
+			}
+			if err != nil {
+				return fmt.Errorf("%s[%d] (%s): %w", field, i, mod.Name, err)
+			}
+		}
+		return nil
+	}
+	if err := check("modules", cfg.Modules); err != nil {
+		return err
+	}
+	for i, section := range cfg.Layout.Sections {
+		if err := check(fmt.Sprintf("layout.sections[%d].modules", i), section.Modules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sampleInfo is empty system information with one element in each list, so
+// that templates can range over them.
+var sampleInfo = &collectors.SystemInfo{
+	GPU:         []string{""},
+	Network:     []collectors.NetworkInfo{{}},
+	ModuleCosts: []collectors.ModuleCost{{}},
+}
+
+// sampleIndex is the index builtin for validation: an index past the end of a
+// list, or a missing map key, gives the element type's zero value.
+func sampleIndex(item reflect.Value, indexes ...reflect.Value) (reflect.Value, error) {
+	for _, index := range indexes {
+		for item.Kind() == reflect.Interface || item.Kind() == reflect.Pointer {
+			if item.IsNil() {
+				return reflect.Value{}, fmt.Errorf("index of nil pointer")
+			}
+			item = item.Elem()
+		}
+		if index.Kind() == reflect.Interface {
+			index = index.Elem()
+		}
+		switch item.Kind() {
+		case reflect.Slice, reflect.Array, reflect.String:
+			var i int64
+			switch {
+			case index.CanInt():
+				i = index.Int()
+			case index.CanUint():
+				i = int64(index.Uint())
+			default:
+				return reflect.Value{}, fmt.Errorf("cannot index %s with %s", item.Type(), index.Type())
+			}
+			switch {
+			case i < 0:
+				return reflect.Value{}, fmt.Errorf("index out of range: %d", i)
+			case i >= int64(item.Len()) && item.Kind() == reflect.String:
+				item = reflect.Zero(reflect.TypeFor[byte]())
+			case i >= int64(item.Len()):
+				item = reflect.Zero(item.Type().Elem())
+			default:
+				item = item.Index(int(i))
+			}
+		case reflect.Map:
+			if !index.IsValid() || !index.Type().AssignableTo(item.Type().Key()) {
+				return reflect.Value{}, fmt.Errorf("cannot index %s with %v", item.Type(), index)
+			}
+			value := item.MapIndex(index)
+			if !value.IsValid() {
+				value = reflect.Zero(item.Type().Elem())
+			}
+			item = value
+		default:
+			return reflect.Value{}, fmt.Errorf("can't index item of type %s", item.Type())
+		}
+	}
+	return item, nil
+}
+
+func (m *CustomModule) Name() string { return m.name }
+
+// Render shows the template's output after the module's label, if it has
+// one. A template that fails or outputs nothing hides the module.
+func (m *CustomModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
+	value, err := m.execute(info, styles)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: custom module %s failed: %v\n", m.name, err)
+		return ""
+	}
+	if value == "" {
+		return ""
+	}
+	if m.label == "" {
+		_, icon := m.labelIcon("", "")
+		if icon != "" {
+			return styles.Separator.Render(icon) + " " + value
+		}
+		return value
+	}
+	return m.field(m.label, value, nil, styles)
+}
+
+func (m *CustomModule) execute(info *collectors.SystemInfo, styles theme.Styles) (string, error) {
+	return m.executeFuncs(info, m.funcs(styles))
+}
+
+func (m *CustomModule) executeFuncs(info *collectors.SystemInfo, funcs template.FuncMap) (string, error) {
+	var b strings.Builder
+	if err := template.Must(m.tmpl.Clone()).Funcs(funcs).Execute(&b, info); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// funcs is the helper library available to templates:
+//
+//	bytes N            a size in the module's unit, e.g. "1.5 GiB"
+//	percent PART TOTAL PART as a whole percentage of TOTAL
+//	bar WIDTH PERCENT  a WIDTH-cell bar filled to PERCENT, in the glyphs of
+//	                   the module's bar option
+//	color NAME TEXT    TEXT in a theme color (primary, secondary, accent,
+//	                   label, value, border) or a hex color
+//	truncate N TEXT    TEXT cut to N cells
+func (m *CustomModule) funcs(styles theme.Styles) template.FuncMap {
+	return template.FuncMap{
+		"bytes": func(n any) (string, error) {
+			value, err := toFloat(n)
+			if err != nil {
+				return "", err
+			}
+			return m.bytes(uint64(max(value, 0))), nil
+		},
+		"percent": func(part, total any) (float64, error) {
+			p, err := toFloat(part)
+			if err != nil {
+				return 0, err
+			}
+			t, err := toFloat(total)
+			if err != nil {
+				return 0, err
+			}
+			if t == 0 {
+				return 0, nil
+			}
+			return math.Round(p / t * 100), nil
+		},
+		"bar": func(width int, percent any) (string, error) {
+			p, err := toFloat(percent)
+			if err != nil {
+				return "", err
+			}
+			opts := BarOptions{Width: width}
+			if m.bar != nil {
+				opts.Glyphs = m.bar.Glyphs
+			}
+			filled, empty := sdk.BarCells(p, opts)
+			return filled + empty, nil
+		},
+		"color": func(name, text string) (string, error) {
+			style, err := colorStyle(name, styles)
+			if err != nil {
+				return "", err
+			}
+			return style.Render(text), nil
+		},
+		"truncate": func(n int, text string) string {
+			return runewidth.Truncate(text, n, "...")
+		},
+	}
+}
+
+// themeColors are the color names templates can use, in the order of
+// Styles.ASCIIColors.
+var themeColors = []string{"primary", "secondary", "accent", "label", "value", "border"}
+
+func colorStyle(name string, styles theme.Styles) (lipgloss.Style, error) {
+	if strings.HasPrefix(name, "#") {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(name)), nil
+	}
+	for i, color := range themeColors {
+		if name != color {
+			continue
+		}
+		if i < len(styles.ASCIIColors) {
+			return styles.ASCIIColors[i], nil
+		}
+		return lipgloss.NewStyle(), nil
+	}
+	return lipgloss.Style{}, fmt.Errorf("unknown color %q (use %s, or a hex color)", name, strings.Join(themeColors, ", "))
+}
+
+// toFloat converts the numbers found in SystemInfo, and numeric strings, to
+// float64.
+func toFloat(v any) (float64, error) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", n)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("%v (%T) is not a number", v, v)
+	}
+}
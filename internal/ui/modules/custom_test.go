@@ -0,0 +1,46 @@
+package modules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/howieduhzit/bubblefetch/internal/config"
+)
+
+func TestValidateCustom(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  string
+	}{
+		{name: "fields", template: "{{.OS}} {{.Memory.Used | bytes}}"},
+		{name: "first GPU", template: "{{index .GPU 0}}"},
+		{name: "second GPU", template: "{{index .GPU 1}}"},
+		{name: "third interface", template: "{{(index .Network 2).IPv4}}"},
+		{name: "ranged interfaces", template: "{{range .Network}}{{.Interface}}{{end}}"},
+		{name: "unknown field", template: "{{.Nope}}", wantErr: "can't evaluate field Nope"},
+		{name: "unknown field of third interface", template: "{{(index .Network 2).Nope}}", wantErr: "can't evaluate field Nope"},
+		{name: "unknown field after fourth GPU", template: "{{index .GPU 3}}{{.Bogus}}", wantErr: "can't evaluate field Bogus"},
+		{name: "negative index", template: "{{index .GPU -1}}", wantErr: "index out of range"},
+		{name: "index of a string field", template: "{{index .OS 5}}"},
+		{name: "unknown function", template: "{{nope .OS}}", wantErr: `function "nope" not defined`},
+		{name: "unknown color", template: `{{color "pink" .OS}}`, wantErr: `unknown color "pink"`},
+		{name: "syntax", template: "{{.OS", wantErr: "unclosed action"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewDefault()
+			cfg.Modules = []config.ModuleConfig{{Name: "custom", Type: config.ModuleTypeCustom, Template: tt.template}}
+			err := ValidateCustom(cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateCustom() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateCustom() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
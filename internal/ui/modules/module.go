@@ -2,12 +2,14 @@ package modules
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/howieduhzit/bubblefetch/internal/collectors"
+	"github.com/howieduhzit/bubblefetch/internal/config"
 	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
-	"github.com/mattn/go-runewidth"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
 )
 
 // Module represents a displayable system information module
@@ -16,6 +18,45 @@ type Module interface {
 	Render(info *collectors.SystemInfo, styles theme.Styles) string
 }
 
+// Data is the structured form of a module's output, used by exports.
+type Data struct {
+	Label string
+	Value string
+	Icon  string
+	Lines []string
+	// Source is the export source of the output, when it is not
+	// "external".
+	Source string
+}
+
+// DataModule is implemented by modules that can report their output as
+// structured data rather than only as rendered text.
+type DataModule interface {
+	Module
+	Data(info *collectors.SystemInfo) (Data, bool)
+}
+
+// Overflow policies for output wider than the space the layout gives it.
+const (
+	OverflowTruncate = "truncate"
+	OverflowWrap     = "wrap"
+)
+
+// OverflowModule is implemented by modules that choose how their output is
+// fitted to a narrow terminal. Other modules are truncated.
+type OverflowModule interface {
+	Module
+	Overflow() string
+}
+
+// OverflowOf returns the module's overflow policy.
+func OverflowOf(m Module) string {
+	if om, ok := m.(OverflowModule); ok && om.Overflow() == OverflowWrap {
+		return OverflowWrap
+	}
+	return OverflowTruncate
+}
+
 // pluginManager holds the global plugin manager instance
 var pluginManager PluginManager
 
@@ -29,64 +70,197 @@ func InitPlugins(pm PluginManager) {
 	pluginManager = pm
 }
 
-// Factory creates modules by name
-func Factory(name string) Module {
+// IsPlugin reports whether name resolves to a plugin rather than a built-in
+// module.
+func IsPlugin(name string) bool {
+	if pluginManager == nil {
+		return false
+	}
+	_, ok := pluginManager.GetPlugin(name)
+	return ok
+}
+
+// Factory creates the module for a modules list entry. The entry's label,
+// icon, format and unit apply to built-in and custom modules.
+func Factory(cfg config.ModuleConfig) Module {
+	if cfg.Type == config.ModuleTypeCustom {
+		m, err := newCustomModule(cfg)
+		if err != nil {
+			return nil
+		}
+		return m
+	}
+
 	// Check plugins first
 	if pluginManager != nil {
-		if mod, ok := pluginManager.GetPlugin(name); ok {
+		if mod, ok := pluginManager.GetPlugin(cfg.Name); ok {
 			return mod
 		}
 	}
 
 	// Fall back to built-in modules
-	switch name {
+	opts := newOptions(cfg)
+	switch cfg.Name {
 	case "os":
-		return &OSModule{}
+		return &OSModule{opts}
 	case "kernel":
-		return &KernelModule{}
+		return &KernelModule{opts}
 	case "hostname":
-		return &HostnameModule{}
+		return &HostnameModule{opts}
 	case "uptime":
-		return &UptimeModule{}
+		return &UptimeModule{opts}
 	case "cpu":
-		return &CPUModule{}
+		return &CPUModule{opts}
 	case "memory":
-		return &MemoryModule{}
+		return &MemoryModule{opts}
 	case "disk":
-		return &DiskModule{}
+		return &DiskModule{opts}
 	case "shell":
-		return &ShellModule{}
+		return &ShellModule{opts}
 	case "terminal":
-		return &TerminalModule{}
+		return &TerminalModule{opts}
 	case "de":
-		return &DEModule{}
+		return &DEModule{opts}
 	case "wm":
-		return &WMModule{}
+		return &WMModule{opts}
 	case "gpu":
-		return &GPUModule{}
+		return &GPUModule{opts}
 	case "network":
-		return &NetworkModule{}
+		return &NetworkModule{opts}
 	case "localip":
-		return &LocalIPModule{}
+		return &LocalIPModule{opts}
 	case "publicip":
-		return &PublicIPModule{}
+		return &PublicIPModule{opts}
 	case "battery":
-		return &BatteryModule{}
+		return &BatteryModule{opts}
 	case "costs":
-		return &ModuleCostModule{}
+		return &ModuleCostModule{opts}
 	default:
 		return nil
 	}
 }
 
-func renderField(label, value string, styles theme.Styles, separator string) string {
-	if icon, ok := labelIcons[label]; ok {
+// options is how a modules list entry changes a built-in module's output.
+type options struct {
+	label  string
+	icon   string
+	format string
+	unit   string
+	bar    *config.BarConfig
+}
+
+func newOptions(cfg config.ModuleConfig) options {
+	return options{label: cfg.Label, icon: cfg.Icon, format: cfg.Format, unit: cfg.Unit, bar: cfg.Bar}
+}
+
+// labelIcon returns the label and icon to show in place of a module's own.
+func (o options) labelIcon(label, icon string) (string, string) {
+	if o.label != "" {
+		label = o.label
+	}
+	switch o.icon {
+	case "":
+	case "none":
+		icon = ""
+	default:
+		icon = o.icon
+	}
+	return label, icon
+}
+
+// field renders a module's "Label: value" line. vars are the placeholders a
+// format can use besides {value}, which is the module's usual value. A bar
+// var, set by addBar, goes where the format puts {bar}, or after the value.
+func (o options) field(label, value string, vars map[string]string, styles theme.Styles) string {
+	label, icon := o.labelIcon(label, labelIcons[label])
+	format := o.format
+	if format == "" {
+		format = "{value}"
+	}
+	bar, hasBar := vars["bar"]
+	if hasBar && !strings.Contains(format, "{bar}") {
+		format += " {bar}"
+	}
+
+	// Style the text around the bar, which has its own colors.
+	parts := strings.Split(format, "{bar}")
+	for i, part := range parts {
+		if text := expandFormat(part, value, vars); text != "" {
+			parts[i] = styles.Value.Render(text)
+		} else {
+			parts[i] = ""
+		}
+	}
+	return renderField(icon, label, strings.Join(parts, bar), styles, ": ")
+}
+
+// addBar adds the module's usage bar to vars, if it has one.
+func (o options) addBar(vars map[string]string, percent float64, thresholds Thresholds, styles theme.Styles) {
+	if o.bar.Enabled() {
+		vars["bar"] = Bar(percent, barOptions(o.bar, thresholds), styles)
+	}
+}
+
+// bytes formats a size in the configured unit, or with sdk.FormatBytes.
+func (o options) bytes(n uint64) string {
+	size, ok := config.ByteUnits[o.unit]
+	switch {
+	case !ok:
+		return sdk.FormatBytes(n)
+	case size == 1:
+		return fmt.Sprintf("%d B", n)
+	default:
+		return fmt.Sprintf("%.1f %s", float64(n)/float64(size), o.unit)
+	}
+}
+
+// usage formats a used/total pair as the value of the memory and disk
+// modules, with the placeholders their formats can use and their bar.
+func (o options) usage(used, total uint64, styles theme.Styles) (string, map[string]string) {
+	percent := 0.0
+	if total > 0 {
+		percent = float64(used) / float64(total) * 100
+	}
+	free := uint64(0)
+	if total > used {
+		free = total - used
+	}
+	vars := map[string]string{
+		"used":    o.bytes(used),
+		"total":   o.bytes(total),
+		"free":    o.bytes(free),
+		"percent": fmt.Sprintf("%.0f", percent),
+	}
+	o.addBar(vars, percent, UsageThresholds, styles)
+	return fmt.Sprintf("%s / %s", vars["used"], vars["total"]), vars
+}
+
+var placeholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// expandFormat fills in a format's {placeholders}. Unknown placeholders are
+// left as written.
+func expandFormat(format, value string, vars map[string]string) string {
+	return placeholder.ReplaceAllStringFunc(format, func(match string) string {
+		name := match[1 : len(match)-1]
+		if name == "value" {
+			return value
+		}
+		if v, ok := vars[name]; ok {
+			return v
+		}
+		return match
+	})
+}
+
+// renderField joins an icon, label and value that is already styled.
+func renderField(icon, label, value string, styles theme.Styles, separator string) string {
+	if icon != "" {
 		return lipgloss.JoinHorizontal(
 			lipgloss.Left,
 			styles.Separator.Render(icon),
 			styles.Label.Render(" "+label),
 			styles.Separator.Render(separator),
-			styles.Value.Render(value),
+			value,
 		)
 	}
 
@@ -94,35 +268,10 @@ func renderField(label, value string, styles theme.Styles, separator string) str
 		lipgloss.Left,
 		styles.Label.Render(label),
 		styles.Separator.Render(separator),
-		styles.Value.Render(value),
+		value,
 	)
 }
 
-func truncateValue(value string, maxWidth int) string {
-	if maxWidth <= 0 {
-		return value
-	}
-	if runewidth.StringWidth(value) <= maxWidth {
-		return value
-	}
-	if maxWidth <= 3 {
-		return strings.Repeat(".", maxWidth)
-	}
-
-	var b strings.Builder
-	width := 0
-	for _, r := range value {
-		w := runewidth.RuneWidth(r)
-		if width+w > maxWidth-3 {
-			break
-		}
-		b.WriteRune(r)
-		width += w
-	}
-	b.WriteString("...")
-	return b.String()
-}
-
 var labelIcons = map[string]string{
 	"OS":        "󰍹",
 	"Kernel":    "󰣇",
@@ -142,124 +291,107 @@ var labelIcons = map[string]string{
 	"Battery":   "󰁹",
 }
 
-func formatBytes(bytes uint64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := uint64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
-}
-
 // OSModule displays operating system information
-type OSModule struct{}
+type OSModule struct{ options }
 
 func (m *OSModule) Name() string { return "os" }
 func (m *OSModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
-	return renderField("OS", info.OS, styles, ": ")
+	return m.field("OS", info.OS, nil, styles)
 }
 
 // KernelModule displays kernel version
-type KernelModule struct{}
+type KernelModule struct{ options }
 
 func (m *KernelModule) Name() string { return "kernel" }
 func (m *KernelModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
-	return renderField("Kernel", info.Kernel, styles, ": ")
+	return m.field("Kernel", info.Kernel, nil, styles)
 }
 
 // HostnameModule displays hostname
-type HostnameModule struct{}
+type HostnameModule struct{ options }
 
 func (m *HostnameModule) Name() string { return "hostname" }
 func (m *HostnameModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
-	return renderField("Host", info.Hostname, styles, ": ")
+	return m.field("Host", info.Hostname, nil, styles)
 }
 
 // UptimeModule displays system uptime
-type UptimeModule struct{}
+type UptimeModule struct{ options }
 
 func (m *UptimeModule) Name() string { return "uptime" }
 func (m *UptimeModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
-	return renderField("Uptime", info.Uptime, styles, ": ")
+	return m.field("Uptime", info.Uptime, nil, styles)
 }
 
 // CPUModule displays CPU information
-type CPUModule struct{}
+type CPUModule struct{ options }
 
 func (m *CPUModule) Name() string { return "cpu" }
 func (m *CPUModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
-	return renderField("CPU", info.CPU, styles, ": ")
+	return m.field("CPU", info.CPU, nil, styles)
 }
 
 // MemoryModule displays memory usage
-type MemoryModule struct{}
+type MemoryModule struct{ options }
 
 func (m *MemoryModule) Name() string { return "memory" }
 func (m *MemoryModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
-	used := formatBytes(info.Memory.Used)
-	total := formatBytes(info.Memory.Total)
-	value := fmt.Sprintf("%s / %s", used, total)
-	return renderField("Memory", value, styles, ": ")
+	value, vars := m.usage(info.Memory.Used, info.Memory.Total, styles)
+	return m.field("Memory", value, vars, styles)
 }
 
 // DiskModule displays disk usage
-type DiskModule struct{}
+type DiskModule struct{ options }
 
 func (m *DiskModule) Name() string { return "disk" }
 func (m *DiskModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
-	used := formatBytes(info.Disk.Used)
-	total := formatBytes(info.Disk.Total)
-	value := fmt.Sprintf("%s / %s", used, total)
-	return renderField("Disk", value, styles, ": ")
+	value, vars := m.usage(info.Disk.Used, info.Disk.Total, styles)
+	return m.field("Disk", value, vars, styles)
 }
 
 // ShellModule displays shell information
-type ShellModule struct{}
+type ShellModule struct{ options }
 
 func (m *ShellModule) Name() string { return "shell" }
 func (m *ShellModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
-	return renderField("Shell", info.Shell, styles, ": ")
+	return m.field("Shell", info.Shell, nil, styles)
 }
 
 // TerminalModule displays terminal information
-type TerminalModule struct{}
+type TerminalModule struct{ options }
 
 func (m *TerminalModule) Name() string { return "terminal" }
 func (m *TerminalModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
 	if info.Terminal == "" {
 		return ""
 	}
-	return renderField("Terminal", info.Terminal, styles, ": ")
+	return m.field("Terminal", info.Terminal, nil, styles)
 }
 
 // DEModule displays desktop environment
-type DEModule struct{}
+type DEModule struct{ options }
 
 func (m *DEModule) Name() string { return "de" }
 func (m *DEModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
 	if info.DE == "" {
 		return ""
 	}
-	return renderField("DE", info.DE, styles, ": ")
+	return m.field("DE", info.DE, nil, styles)
 }
 
 // WMModule displays window manager
-type WMModule struct{}
+type WMModule struct{ options }
 
 func (m *WMModule) Name() string { return "wm" }
 func (m *WMModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
 	if info.WM == "" {
 		return ""
 	}
-	return renderField("WM", info.WM, styles, ": ")
+	return m.field("WM", info.WM, nil, styles)
 }
 
 // GPUModule displays GPU information
-type GPUModule struct{}
+type GPUModule struct{ options }
 
 func (m *GPUModule) Name() string { return "gpu" }
 func (m *GPUModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
@@ -271,12 +403,15 @@ func (m *GPUModule) Render(info *collectors.SystemInfo, styles theme.Styles) str
 	if len(info.GPU) > 1 {
 		gpu = fmt.Sprintf("%s (+%d more)", gpu, len(info.GPU)-1)
 	}
-	gpu = truncateValue(gpu, 64)
-	return renderField("GPU", gpu, styles, ": ")
+	vars := map[string]string{"name": info.GPU[0], "count": fmt.Sprint(len(info.GPU))}
+	return m.field("GPU", gpu, vars, styles)
 }
 
+// Overflow wraps GPU names, which are long but lose meaning when cut short.
+func (m *GPUModule) Overflow() string { return OverflowWrap }
+
 // NetworkModule displays network interface information
-type NetworkModule struct{}
+type NetworkModule struct{ options }
 
 func (m *NetworkModule) Name() string { return "network" }
 func (m *NetworkModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
@@ -286,22 +421,23 @@ func (m *NetworkModule) Render(info *collectors.SystemInfo, styles theme.Styles)
 	// Display the first active network interface
 	net := info.Network[0]
 	value := fmt.Sprintf("%s (%s)", net.Interface, net.IPv4)
-	return renderField("Network", value, styles, ": ")
+	vars := map[string]string{"interface": net.Interface, "ipv4": net.IPv4, "ipv6": net.IPv6, "mac": net.MAC}
+	return m.field("Network", value, vars, styles)
 }
 
 // LocalIPModule displays local IP address
-type LocalIPModule struct{}
+type LocalIPModule struct{ options }
 
 func (m *LocalIPModule) Name() string { return "localip" }
 func (m *LocalIPModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
 	if info.LocalIP == "" {
 		return ""
 	}
-	return renderField("Local IP", info.LocalIP, styles, ": ")
+	return m.field("Local IP", info.LocalIP, nil, styles)
 }
 
 // BatteryModule displays battery information
-type BatteryModule struct{}
+type BatteryModule struct{ options }
 
 func (m *BatteryModule) Name() string { return "battery" }
 func (m *BatteryModule) Render(info *collectors.SystemInfo, styles theme.Styles) string {
@@ -319,5 +455,11 @@ func (m *BatteryModule) Render(info *collectors.SystemInfo, styles theme.Styles)
 		value = fmt.Sprintf("%.0f%% (%s remaining)", info.Battery.Percentage, info.Battery.TimeRemain)
 	}
 
-	return renderField("Battery", value, styles, ": ")
+	vars := map[string]string{
+		"percent": fmt.Sprintf("%.0f", info.Battery.Percentage),
+		"status":  status,
+		"time":    info.Battery.TimeRemain,
+	}
+	m.addBar(vars, info.Battery.Percentage, ChargeThresholds, styles)
+	return m.field("Battery", value, vars, styles)
 }
@@ -5,7 +5,7 @@ import (
 	"github.com/howieduhzit/bubblefetch/internal/ui/theme"
 )
 
-type PublicIPModule struct{}
+type PublicIPModule struct{ options }
 
 func (m *PublicIPModule) Name() string {
 	return "publicip"
@@ -15,5 +15,5 @@ func (m *PublicIPModule) Render(info *collectors.SystemInfo, styles theme.Styles
 	if info.PublicIP == "" {
 		return ""
 	}
-	return renderField("Public IP", info.PublicIP, styles, ": ")
+	return m.field("Public IP", info.PublicIP, nil, styles)
 }
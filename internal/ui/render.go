@@ -2,7 +2,6 @@ package ui
 
 import (
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/howieduhzit/bubblefetch/internal/collectors"
@@ -17,55 +16,123 @@ func Render(cfg *config.Config, info *collectors.SystemInfo, err error) string {
 	if loadErr != nil {
 		thm, _ = theme.Load("default")
 	}
-	styles := thm.GetStyles()
 
 	if err != nil {
-		return styles.Value.Render("Error: " + err.Error())
+		return thm.GetStyles().Value.Render("Error: " + err.Error())
 	}
 
-	thm.ApplyAutoASCII(info.OS)
-
-	var content strings.Builder
+	return RenderTheme(cfg, thm, info)
+}
 
-	var asciiArt string
-	if thm.Layout.ShowASCII {
-		asciiArt = styles.ASCII.Render(thm.ASCII)
+// RenderExport builds the fetch output for exports. It is fitted to
+// layout.width when that is set, but never to the terminal, so an export
+// looks the same wherever it is made.
+func RenderExport(cfg *config.Config, info *collectors.SystemInfo) string {
+	thm, err := theme.Load(cfg.Theme)
+	if err != nil {
+		thm, _ = theme.Load("default")
 	}
+	return renderWidth(cfg, thm, info, cfg.Layout.Width)
+}
 
-	var moduleLines []string
-	for _, moduleName := range cfg.Modules {
-		module := modules.Factory(moduleName)
-		if module == nil {
-			continue
-		}
-		start := time.Now()
-		rendered := module.Render(info, styles)
-		renderDuration := time.Since(start)
-		if rendered != "" {
-			if !collectors.HasModuleCost(info, module.Name()) {
-				collectors.AddModuleCost(info, module.Name(), renderDuration)
-			}
-			moduleLines = append(moduleLines, rendered)
-		}
+// RenderTheme builds the static fetch output with an already loaded theme.
+// The output is fitted to the terminal, or to layout.width: a layout that
+// fits as is wins, then module values are truncated or wrapped to fit beside
+// the ASCII art, and only then is the art dropped. Terminals narrower than
+// layout.min_ascii_width never show the art.
+func RenderTheme(cfg *config.Config, thm *theme.Theme, info *collectors.SystemInfo) string {
+	width := cfg.Layout.Width
+	if width == 0 {
+		width = TerminalWidth()
 	}
+	return renderWidth(cfg, thm, info, width)
+}
+
+// renderWidth builds the static fetch output fitted to width columns; 0
+// leaves it unfitted.
+func renderWidth(cfg *config.Config, thm *theme.Theme, info *collectors.SystemInfo, width int) string {
+	styles := thm.GetStyles()
+	thm.ApplyAutoASCII(info.OS)
+
+	spec := resolveLayout(cfg, thm)
+	sections := renderSections(spec, cfg.Layout.Overflow, info, styles)
+	header := expandLines(spec.header, cfg, info, styles)
+	footer := expandLines(spec.footer, cfg, info, styles)
 
-	moduleContent := strings.Join(moduleLines, "\n")
+	art := renderLogo(cfg, thm, info, styles)
+	asciiArt := art.text
 
-	if thm.Layout.ShowASCII && asciiArt != "" {
-		content.WriteString(lipgloss.JoinHorizontal(
-			lipgloss.Top,
+	frame := func(content string) string {
+		result := styles.Container.Render(content)
+		if thm.Layout.BorderStyle != "none" && thm.Layout.BorderStyle != "" {
+			result = styles.Border.Render(result)
+		}
+		return result
+	}
+	render := func(variant layout, contentWidth int) string {
+		return frame(placeASCII(
 			asciiArt,
-			strings.Repeat(" ", thm.Layout.Padding),
-			moduleContent,
+			arrangeModules(sections, header, footer, variant.columns, contentWidth, styles),
+			variant.asciiPosition,
+			thm.Layout.Padding,
 		))
-	} else {
-		content.WriteString(moduleContent)
 	}
 
-	result := styles.Container.Render(content.String())
-	if thm.Layout.BorderStyle != "none" && thm.Layout.BorderStyle != "" {
-		result = styles.Border.Render(result)
+	if width == 0 {
+		return finishLogo(render(spec, 0), art, func() string {
+			asciiArt = art.fallback
+			return render(spec, 0)
+		})
+	}
+
+	attempts := fitAttempts(fallbacks(spec), width < cfg.Layout.MinASCIIWidth)
+	frameWidth := lipgloss.Width(frame(""))
+	var result string
+	var chosen fitAttempt
+	var chosenWidth int
+	for i, attempt := range attempts {
+		contentWidth := 0
+		if attempt.fit {
+			contentWidth = width - frameWidth
+			if asciiArt != "" && (attempt.asciiPosition == "left" || attempt.asciiPosition == "right") {
+				contentWidth -= lipgloss.Width(asciiArt) + thm.Layout.Padding
+			}
+			columnWidth := (contentWidth - (attempt.columns-1)*len(columnGap)) / attempt.columns
+			if columnWidth < minColumnWidth && i < len(attempts)-1 {
+				continue
+			}
+		}
+		// Too narrow for even the frame: render unfitted and cut below.
+		contentWidth = max(contentWidth, 0)
+		result = render(attempt.layout, contentWidth)
+		chosen, chosenWidth = attempt, contentWidth
+		if lipgloss.Width(result) <= width {
+			break
+		}
+	}
+
+	// When no layout fits, every line is truncated to the width.
+	clip := func(s string) string {
+		if lipgloss.Width(s) <= width {
+			return s
+		}
+		return FitWidth(s, width, modules.OverflowTruncate)
 	}
+	return finishLogo(clip(result), art, func() string {
+		asciiArt = art.fallback
+		return clip(render(chosen.layout, chosenWidth))
+	})
+}
 
-	return result
+// finishLogo draws an image logo over the space reserved for it. When the
+// output is too tall for the terminal, the image could not be placed after
+// scrolling, so the output is rendered again with the text fallback.
+func finishLogo(result string, art logoArt, fallback func() string) string {
+	if art.image == "" {
+		return result
+	}
+	if height := TerminalHeight(); height > 0 && strings.Count(result, "\n")+1 >= height {
+		return fallback()
+	}
+	return drawLogo(result, art.image)
 }
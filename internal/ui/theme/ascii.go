@@ -2,8 +2,12 @@ package theme
 
 import (
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 var (
@@ -347,6 +351,162 @@ const (
 `
 )
 
+// ASCII art for more distributions. These use neofetch color markup; see
+// RenderASCII.
+const (
+	EndeavourOSASCII = `
+${c1}            /${c2}o${c3}.
+${c1}          /${c2}sssso${c3}-
+${c1}        /${c2}ossssssso${c3}:
+${c1}      /${c2}ssssssssssso${c3}+
+${c1}    /${c2}ssssssssssssssso${c3}+
+${c1}  //${c2}osssssssssssssssso${c3}+-
+${c1} .+${c2}ossssssssssssssssso${c3}+-
+${c3}  -++++++++++++++++++-
+`
+
+	GarudaASCII = `
+${c1}        .----------.
+${c1}      .'  ________  '.
+${c1}     /  .'        '.__\
+${c1}    |  |     ${c2}________
+${c1}    |  |    ${c2}|_____  |
+${c1}     \  '.________.'  /
+${c1}      '.____________.'
+`
+
+	ArtixASCII = `
+${c1}          /\
+${c1}         /  \
+${c1}        /'. .\
+${c1}       /    '.\
+${c1}      /  .'    \
+${c1}     /.'  .  '. \
+${c1}    /  .'   '.   \
+${c1}   /.'         '. \
+`
+
+	CentOSASCII = `
+${c1}            /\
+${c2}    _______${c1}/  \${c4}_______
+${c2}   |\      ${c1}\  /${c4}      /|
+${c2}   |  \     ${c1}\/${c4}     /  |
+${c2}   |    \   ${c1}||${c4}   /    |
+${c1}  /${c2}______${c1}\==++==/${c4}______${c1}\
+${c1}  \${c3}------${c1}/==++==\${c5}------${c1}/
+${c3}   |    /   ${c1}||${c5}   \    |
+${c3}   |  /     ${c1}/\${c5}     \  |
+${c3}   |/______${c1}/  \${c5}______\|
+${c1}            \  /
+${c1}             \/
+`
+
+	RockyASCII = `
+${c1}        .---------.
+${c1}      .'           '.
+${c1}     /                \
+${c1}    |       /\         |
+${c1}    |      /  \   /\   |
+${c1}    |     /    \_/  \  |
+${c1}     \   /           /
+${c1}      './          .'
+${c1}        '---------'
+`
+
+	AlmaLinuxASCII = `
+${c1}    .--.       ${c2}.-.
+${c1}   (    )     ${c2}(   )
+${c1}    '--'  ${c3}.--. ${c2}'-'
+${c3}         (    )
+${c4}   .-.    ${c3}'--'  ${c5}.--.
+${c4}  (   )        ${c5}(    )
+${c4}   '-'          ${c5}'--'
+`
+
+	RHELASCII = `
+${c1}           .MMM..:MMMMMMM
+${c1}          MMMMMMMMMMMMMMMMMM
+${c1}          MMMMMMMMMMMMMMMMMMMM.
+${c1}         MMMMMMMMMMMMMMMMMMMMMM
+${c1}        ,MMMMMMMMMMMMMMMMMMMMMM:
+${c1}        MMMMMMMMMMMMMMMMMMMMMMMM
+${c1}  .MMMM'  MMMMMMMMMMMMMMMMMMMMMM
+${c1} MMMMMM    'MMMMMMMMMMMMMMMMMMMM.
+${c1}MMMMMMMM      MMMMMMMMMMMMMMMMMM .
+${c1}'MMMMMMMMM.                'MMMMM.
+${c1}  'MMMMMMMMMMM.              'MMM
+${c1}     'MMMMMMMMMMMMMMMMMMM.     .MM'
+${c1}          'MMMMMMMMMMMMMMMMMMMMM'
+`
+
+	ElementaryASCII = `
+${c1}         eeeeeeeeeeeeeeeee
+${c1}      eeeee  eeeeeeeeeeee   eeeee
+${c1}   eeee   eeeee       eee     eeee
+${c1}  eeee   eeee          eee     eeee
+${c1} eee    eee            eee       eee
+${c1} eee   eee            eee        eee
+${c1} ee    eee           eeee       eeee
+${c1} ee    eee         eeeee      eeeeee
+${c1} ee    eee       eeeee      eeeee ee
+${c1} eee   eeee   eeeeee      eeeee  eee
+${c1} eee    eeeeeeeeee     eeeeee    eee
+${c1}  eeeeeeeeeeeeeeeeeeeeeeee    eeeee
+${c1}   eeeeeeee eeeeeeeeeeee      eeee
+${c1}     eeeee                 eeeee
+${c1}       eeeeeee         eeeeeee
+${c1}          eeeeeeeeeeeeeeeee
+`
+
+	ZorinASCII = `
+${c1}      '.:///////////:.'
+${c1}     ./////////////////.
+${c1}    ..                 ..
+${c1}   ..:///////////////////:..
+${c1}  '/////////////////////////'
+
+${c1}  './///////////////////////.'
+${c1}   ..:///////////////////:..
+${c1}    ..                 ..
+${c1}     './///////////////.'
+${c1}       '.:///////////:.'
+`
+
+	SlackwareASCII = `
+${c1}     ____________
+${c1}    /  _________|
+${c1}    | |
+${c1}    \ \_________
+${c1}     \_________ \
+${c1}               | |
+${c1}   ____________/ /
+${c1}  |_____________/
+`
+
+	OpenBSDASCII = `
+${c3}             _____
+${c3}           \-     -/
+${c3}        \_/         \
+${c3}        |        ${c2}O O${c3} |
+${c3}        |_  <   )  3 )
+${c3}        / \         /
+${c3}           /-_____-\
+`
+
+	RaspbianASCII = `
+${c1}    .~~.   .~~.
+${c1}   '. \ ' ' / .'
+${c2}    .~ .~~~..~.
+${c2}   : .~.'~'.~. :
+${c2}  ~ (   ) (   ) ~
+${c2} ( : '~'.~.'~' : )
+${c2}  ~ .~ (   ) ~. ~
+${c2}   (  : '~' :  )
+${c2}    '~ .~~~. ~'
+${c2}        '~'
+`
+)
+
 // DetectOS attempts to detect the operating system and distribution (cached)
 func DetectOS() string {
 	detectedOSCacheOnce.Do(func() {
@@ -398,8 +558,13 @@ func detectOSInternal() string {
 	return "linux"
 }
 
-// GetASCIIArt returns ASCII art for the detected or specified OS
+// GetASCIIArt returns ASCII art for the detected or specified OS. Art in
+// ~/.config/bubblefetch/ascii takes precedence over the built-in art.
 func GetASCIIArt(osName string) string {
+	if art, ok := userASCIIArt(osName); ok {
+		return art
+	}
+
 	// Normalize OS name
 	osName = strings.ToLower(osName)
 
@@ -438,7 +603,111 @@ func GetASCIIArt(osName string) string {
 		return FreeBSDASCII
 	case strings.Contains(osName, "alpine"):
 		return AlpineASCII
+	case strings.Contains(osName, "endeavouros"):
+		return EndeavourOSASCII
+	case strings.Contains(osName, "garuda"):
+		return GarudaASCII
+	case strings.Contains(osName, "artix"):
+		return ArtixASCII
+	case strings.Contains(osName, "centos"):
+		return CentOSASCII
+	case strings.Contains(osName, "rocky"):
+		return RockyASCII
+	case strings.Contains(osName, "almalinux"), strings.Contains(osName, "alma"):
+		return AlmaLinuxASCII
+	case strings.Contains(osName, "rhel"), strings.Contains(osName, "red hat"), strings.Contains(osName, "redhat"):
+		return RHELASCII
+	case strings.Contains(osName, "elementary"):
+		return ElementaryASCII
+	case strings.Contains(osName, "zorin"):
+		return ZorinASCII
+	case strings.Contains(osName, "slackware"):
+		return SlackwareASCII
+	case strings.Contains(osName, "openbsd"):
+		return OpenBSDASCII
+	case strings.Contains(osName, "raspbian"):
+		return RaspbianASCII
 	default:
 		return DefaultASCII
 	}
 }
+
+// userASCIIArt reads art from ~/.config/bubblefetch/ascii/<key>.txt, where
+// the key is the OS name or distribution ID in lower case with spaces as
+// dashes. Shorter keys are tried too, so "Linux Mint 21" finds
+// linux-mint-21.txt, then linux-mint.txt, then linux.txt.
+func userASCIIArt(osName string) (string, bool) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", false
+	}
+	dir := filepath.Join(home, ".config", "bubblefetch", "ascii")
+
+	fields := strings.Fields(strings.ToLower(osName))
+	for n := len(fields); n > 0; n-- {
+		key := strings.Join(fields[:n], "-")
+		if strings.ContainsAny(key, `/\`) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, key+".txt"))
+		if err == nil {
+			return "\n" + strings.Trim(string(data), "\r\n") + "\n", true
+		}
+	}
+	return "", false
+}
+
+// asciiMarkup matches neofetch color markup in ASCII art.
+var asciiMarkup = regexp.MustCompile(`\$\{c([1-6])\}`)
+
+// RenderASCII styles ASCII art. Art may switch colors with neofetch's
+// ${c1}..${c6} markup, which maps to the theme's primary, secondary, accent,
+// label, value and border colors; a color carries on to the following lines
+// until the next switch. Art without markup is drawn in the ASCII style.
+func RenderASCII(art string, styles Styles) string {
+	if !asciiMarkup.MatchString(art) {
+		return styles.ASCII.Render(art)
+	}
+
+	style := styles.ASCII
+	lines := strings.Split(art, "\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, lipgloss.Width(StripASCIIMarkup(line)))
+	}
+
+	for i, line := range lines {
+		var b strings.Builder
+		plain := 0
+		last := 0
+		for _, match := range asciiMarkup.FindAllStringSubmatchIndex(line, -1) {
+			text := line[last:match[0]]
+			b.WriteString(renderASCIISegment(style, text))
+			plain += lipgloss.Width(text)
+			index := int(line[match[2]] - '1')
+			if index < len(styles.ASCIIColors) {
+				style = styles.ASCIIColors[index]
+			}
+			last = match[1]
+		}
+		text := line[last:]
+		b.WriteString(renderASCIISegment(style, text))
+		plain += lipgloss.Width(text)
+		// Pad like lipgloss does for unmarked art, so the art stays a block.
+		b.WriteString(strings.Repeat(" ", width-plain))
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderASCIISegment(style lipgloss.Style, text string) string {
+	if text == "" {
+		return ""
+	}
+	return style.Render(text)
+}
+
+// StripASCIIMarkup removes ${c1}..${c6} color markup from ASCII art.
+func StripASCIIMarkup(art string) string {
+	return asciiMarkup.ReplaceAllString(art, "")
+}
@@ -0,0 +1,401 @@
+package theme
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// importers read terminal color schemes. A theme name of the form
+// "<kind>" or "<kind>:<path>" selects one; the bare kind uses the tool's
+// default file location.
+var importers = map[string]struct {
+	defaultPaths []string
+	read         func(path string) (palette, error)
+}{
+	"base16":    {read: readBase16},
+	"wal":       {defaultPaths: []string{"~/.cache/wal/colors.json"}, read: readWal},
+	"alacritty": {defaultPaths: []string{"~/.config/alacritty/alacritty.toml", "~/.config/alacritty/alacritty.yml"}, read: readAlacritty},
+	"kitty":     {defaultPaths: []string{"~/.config/kitty/kitty.conf"}, read: readKitty},
+}
+
+// palette is a terminal color scheme: background, foreground and the 16 ANSI
+// colors (0-7 normal, 8-15 bright). Unset entries are empty.
+type palette struct {
+	background string
+	foreground string
+	ansi       [16]string
+}
+
+// IsImport reports whether name refers to an imported terminal color scheme
+// rather than a theme file. A bare importer name like "kitty" is a theme
+// file when one by that name exists; "kitty:<path>" is always an import.
+func IsImport(name string) bool {
+	kind, _, hasPath := strings.Cut(name, ":")
+	if _, ok := importers[kind]; !ok {
+		return false
+	}
+	if hasPath {
+		return true
+	}
+	_, err := Path(name)
+	return err != nil
+}
+
+// importTheme builds a theme from a terminal color scheme, using the
+// built-in theme's layout and filling unset palette slots from its colors.
+func importTheme(name string) (*Theme, error) {
+	kind, path, _ := strings.Cut(name, ":")
+	importer := importers[kind]
+
+	if path == "" {
+		for _, candidate := range importer.defaultPaths {
+			candidate = expandHome(candidate)
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+		if path == "" {
+			if len(importer.defaultPaths) == 0 {
+				return nil, fmt.Errorf("theme %q: a file path is required, e.g. %s:~/.config/base16/scheme.yaml", name, kind)
+			}
+			return nil, fmt.Errorf("theme %q: %s not found", name, importer.defaultPaths[0])
+		}
+	}
+
+	pal, err := importer.read(expandHome(path))
+	if err != nil {
+		return nil, fmt.Errorf("theme %q: %w", name, err)
+	}
+
+	theme := builtinTheme()
+	theme.Name = name
+	pal.apply(&theme.Colors)
+	return theme, nil
+}
+
+// apply maps palette slots onto theme colors, mirroring the default theme:
+// blue primary, magenta secondary, red accent, yellow labels, green values
+// and a bright-black border.
+func (p palette) apply(c *Colors) {
+	set := func(field *string, value string) {
+		if value = normalizeColor(value); value != "" {
+			*field = value
+		}
+	}
+	set(&c.Primary, p.ansi[4])
+	set(&c.Secondary, p.ansi[5])
+	set(&c.Accent, p.ansi[1])
+	set(&c.Label, p.ansi[3])
+	set(&c.Value, p.ansi[2])
+	set(&c.Border, p.ansi[8])
+	set(&c.Background, p.background)
+}
+
+// readBase16 reads a base16 scheme, either the classic flat format or the
+// tinted-theming format with a palette map.
+func readBase16(path string) (palette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return palette{}, err
+	}
+
+	var scheme struct {
+		Palette map[string]string `yaml:"palette"`
+	}
+	if err := yaml.Unmarshal(data, &scheme); err != nil {
+		return palette{}, fmt.Errorf("%s: %w", path, err)
+	}
+	raw := scheme.Palette
+	if len(raw) == 0 {
+		flat := map[string]interface{}{}
+		if err := yaml.Unmarshal(data, &flat); err != nil {
+			return palette{}, fmt.Errorf("%s: %w", path, err)
+		}
+		raw = make(map[string]string)
+		for key, value := range flat {
+			if s, ok := value.(string); ok {
+				raw[key] = s
+			}
+		}
+	}
+	// Slot names are written both as base0D and base0d.
+	slots := make(map[string]string, len(raw))
+	for key, value := range raw {
+		slots[strings.ToLower(key)] = value
+	}
+	slot := func(name string) string {
+		return slots[strings.ToLower(name)]
+	}
+	if slot("base00") == "" && slot("base0D") == "" {
+		return palette{}, fmt.Errorf("%s: no base16 colors (base00-base0F) found", path)
+	}
+
+	// The usual base16 to ANSI mapping, as used by base16-shell.
+	pal := palette{background: slot("base00"), foreground: slot("base05")}
+	normal := []string{"base00", "base08", "base0B", "base0A", "base0D", "base0E", "base0C", "base05"}
+	for i, name := range normal {
+		pal.ansi[i] = slot(name)
+		pal.ansi[i+8] = slot(name)
+	}
+	pal.ansi[8] = slot("base03")
+	pal.ansi[15] = slot("base07")
+	return pal, nil
+}
+
+// readWal reads pywal's colors.json.
+func readWal(path string) (palette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return palette{}, err
+	}
+
+	var scheme struct {
+		Special struct {
+			Background string `json:"background"`
+			Foreground string `json:"foreground"`
+		} `json:"special"`
+		Colors map[string]string `json:"colors"`
+	}
+	if err := json.Unmarshal(data, &scheme); err != nil {
+		return palette{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(scheme.Colors) == 0 {
+		return palette{}, fmt.Errorf("%s: no colors found", path)
+	}
+
+	pal := palette{background: scheme.Special.Background, foreground: scheme.Special.Foreground}
+	for i := range pal.ansi {
+		pal.ansi[i] = scheme.Colors[fmt.Sprintf("color%d", i)]
+	}
+	return pal, nil
+}
+
+var ansiNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// readAlacritty reads the colors from an Alacritty config, following its
+// imports. TOML and the older YAML format are both accepted.
+func readAlacritty(path string) (palette, error) {
+	var values map[string]string
+	var err error
+	if ext := filepath.Ext(path); ext == ".yml" || ext == ".yaml" {
+		values, err = readAlacrittyYAML(path)
+	} else {
+		values, err = readAlacrittyTOML(path, 0)
+	}
+	if err != nil {
+		return palette{}, err
+	}
+
+	pal := palette{
+		background: values["colors.primary.background"],
+		foreground: values["colors.primary.foreground"],
+	}
+	for i, name := range ansiNames {
+		pal.ansi[i] = values["colors.normal."+name]
+		pal.ansi[i+8] = values["colors.bright."+name]
+	}
+	if pal.background == "" && pal.ansi[4] == "" {
+		return palette{}, fmt.Errorf("%s: no colors found", path)
+	}
+	return pal, nil
+}
+
+// readAlacrittyTOML returns the string values of an Alacritty TOML file keyed
+// by dotted path. It understands the subset of TOML used for colors: tables,
+// dotted keys, strings, inline tables and the import array.
+func readAlacrittyTOML(path string, depth int) (map[string]string, error) {
+	if depth > 5 {
+		return nil, fmt.Errorf("%s: too many nested imports", path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	var imports []string
+	table := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			table = strings.Trim(line, "[] ")
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ReplaceAll(strings.TrimSpace(key), " ", "")
+		if table != "" {
+			key = table + "." + key
+		}
+		value = strings.TrimSpace(value)
+		// Arrays, such as import lists, may span several lines.
+		for strings.HasPrefix(value, "[") && !strings.Contains(value, "]") && scanner.Scan() {
+			value += strings.TrimSpace(stripComment(scanner.Text()))
+		}
+
+		switch {
+		case key == "import" || key == "general.import":
+			for _, item := range strings.Split(strings.Trim(value, "[]"), ",") {
+				if item = unquote(item); item != "" {
+					imports = append(imports, item)
+				}
+			}
+		case strings.HasPrefix(value, "{"):
+			for _, pair := range strings.Split(strings.Trim(value, "{}"), ",") {
+				if k, v, ok := strings.Cut(pair, "="); ok {
+					values[key+"."+strings.TrimSpace(k)] = unquote(v)
+				}
+			}
+		default:
+			values[key] = unquote(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Imported files are loaded first, so the importing file wins.
+	merged := make(map[string]string)
+	for _, imported := range imports {
+		imported = expandHome(imported)
+		if !filepath.IsAbs(imported) {
+			imported = filepath.Join(filepath.Dir(path), imported)
+		}
+		importedValues, err := readAlacrittyTOML(imported, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range importedValues {
+			merged[k] = v
+		}
+	}
+	for k, v := range values {
+		merged[k] = v
+	}
+	return merged, nil
+}
+
+func readAlacrittyYAML(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config struct {
+		Colors map[string]map[string]string `yaml:"colors"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	values := make(map[string]string)
+	for group, colors := range config.Colors {
+		for name, value := range colors {
+			values["colors."+group+"."+name] = value
+		}
+	}
+	return values, nil
+}
+
+// readKitty reads the colors from a kitty config, following include lines.
+func readKitty(path string) (palette, error) {
+	values := make(map[string]string)
+	if err := readKittyConf(path, values, 0); err != nil {
+		return palette{}, err
+	}
+
+	pal := palette{background: values["background"], foreground: values["foreground"]}
+	for i := range pal.ansi {
+		pal.ansi[i] = values[fmt.Sprintf("color%d", i)]
+	}
+	if pal.background == "" && pal.ansi[4] == "" {
+		return palette{}, fmt.Errorf("%s: no colors found", path)
+	}
+	return pal, nil
+}
+
+func readKittyConf(path string, values map[string]string, depth int) error {
+	if depth > 5 {
+		return fmt.Errorf("%s: too many nested includes", path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if fields[0] == "include" {
+			included := expandHome(strings.Join(fields[1:], " "))
+			if !filepath.IsAbs(included) {
+				included = filepath.Join(filepath.Dir(path), included)
+			}
+			// kitty ignores missing includes, so do the same.
+			if err := readKittyConf(included, values, depth+1); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		values[fields[0]] = fields[1]
+	}
+	return scanner.Err()
+}
+
+// normalizeColor converts "#RRGGBB", "RRGGBB" and "0xRRGGBB" to "#rrggbb",
+// returning "" for anything else.
+func normalizeColor(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "#")
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	if !hexColorPattern.MatchString("#" + value) {
+		return ""
+	}
+	return "#" + strings.ToLower(value)
+}
+
+func stripComment(line string) string {
+	inQuote := rune(0)
+	for i, r := range line {
+		switch {
+		case inQuote != 0:
+			if r == inQuote {
+				inQuote = 0
+			}
+		case r == '"' || r == '\'':
+			inQuote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func unquote(value string) string {
+	return strings.Trim(strings.TrimSpace(value), `"'`)
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
@@ -2,16 +2,30 @@ package theme
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/howieduhzit/bubblefetch/themes"
 )
 
 type Theme struct {
-	Name      string `json:"name"`
-	Colors    Colors `json:"colors"`
-	ASCII     string `json:"ascii"`
+	Name string `json:"name"`
+	// Extends names a theme whose settings this one starts from; fields
+	// set here override it.
+	Extends string `json:"extends,omitempty"`
+	Colors  Colors `json:"colors"`
+	// Light and Dark override colors on light and dark terminal backgrounds.
+	Light *Colors `json:"light,omitempty"`
+	Dark  *Colors `json:"dark,omitempty"`
+	ASCII string  `json:"ascii"`
+	// Logo is an image shown instead of the ASCII art: a PNG, JPEG or SVG
+	// path, or "auto" for the built-in logo of the detected distribution.
+	Logo      string `json:"logo,omitempty"`
 	Layout    Layout `json:"layout"`
 	asciiAuto bool
 }
@@ -24,6 +38,11 @@ type Colors struct {
 	Value      string `json:"value"`
 	Border     string `json:"border"`
 	Background string `json:"background"`
+	// Good, Warning and Critical color usage bars by level. They default to
+	// the value, label and accent colors.
+	Good     string `json:"good,omitempty"`
+	Warning  string `json:"warning,omitempty"`
+	Critical string `json:"critical,omitempty"`
 }
 
 type Layout struct {
@@ -32,62 +51,251 @@ type Layout struct {
 	Separator   string `json:"separator"`
 	Padding     int    `json:"padding"`
 	BorderStyle string `json:"border_style"`
+	// ASCIIPosition places the ASCII art: left (default), right, top, or
+	// hidden.
+	ASCIIPosition string `json:"ascii_position,omitempty"`
+	// Columns lays modules out in a grid of this many columns (default 1).
+	Columns int `json:"columns,omitempty"`
+	// Sections group modules under titles. When set, they replace the
+	// configured modules list in the fetch view.
+	Sections []Section `json:"sections,omitempty"`
+	// Header and Footer are lines shown above and below the modules. See
+	// the README for placeholders such as {title} and {colors}.
+	Header []string `json:"header,omitempty"`
+	Footer []string `json:"footer,omitempty"`
 }
 
+// Section is a titled group of modules.
+type Section struct {
+	Title   string   `json:"title"`
+	Modules []string `json:"modules"`
+}
+
+// ASCII positions accepted by Layout.ASCIIPosition.
+var ASCIIPositions = []string{"left", "right", "top", "hidden"}
+
 // Styles contains lipgloss styles for the theme
 type Styles struct {
+	Title     lipgloss.Style
 	Label     lipgloss.Style
 	Value     lipgloss.Style
 	Separator lipgloss.Style
 	ASCII     lipgloss.Style
 	Border    lipgloss.Style
 	Container lipgloss.Style
+	// ASCIIColors are the ${c1}..${c6} colors of ASCII art markup. When
+	// empty, marked-up art is drawn in the ASCII style.
+	ASCIIColors []lipgloss.Style
+	// Good, Warning and Critical color usage levels.
+	Good     lipgloss.Style
+	Warning  lipgloss.Style
+	Critical lipgloss.Style
 }
 
-// Load loads a theme by name from the themes directory
+// Load loads a theme by name from the user or local themes directory, then
+// from the themes bundled in the binary, applying any theme it extends.
+// "default" falls back to the built-in theme when no file overrides it; any
+// other missing or invalid theme is an error.
+// Names like "wal" or "base16:<path>" import a terminal color scheme.
 func Load(name string) (*Theme, error) {
-	// Try user config directory first
-	home, err := os.UserHomeDir()
-	if err == nil {
-		userThemePath := filepath.Join(home, ".config", "bubblefetch", "themes", name+".json")
-		if theme, err := loadThemeFile(userThemePath); err == nil {
-			return theme, nil
+	theme, err := Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	theme.resolveASCII()
+	theme.applyVariant()
+	return theme, nil
+}
+
+// LoadFile loads a theme from a file path, applying any theme it extends.
+func LoadFile(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSuffix(filepath.Base(path), ".json")
+	theme, err := parseTheme(path, data, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	theme.resolveASCII()
+	theme.applyVariant()
+	return theme, nil
+}
+
+// Resolve returns a theme with inheritance applied but the ASCII setting
+// and light/dark variants left as written.
+func Resolve(name string) (*Theme, error) {
+	return resolve(name, nil)
+}
+
+// Path returns the file a theme name resolves to. It returns "" for the
+// built-in themes: the default and those bundled in the binary.
+func Path(name string) (string, error) {
+	for _, dir := range themeDirs() {
+		path := filepath.Join(dir, name+".json")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	if name == "default" || name == "" || bundledTheme(name) != nil {
+		return "", nil
+	}
+	return "", fmt.Errorf("theme %q not found", name)
+}
+
+// List returns the names of all available themes: the built-in and bundled
+// themes plus every theme file in the user and local themes directories,
+// sorted.
+func List() []string {
+	seen := map[string]bool{"default": true}
+	if entries, err := themes.FS.ReadDir("."); err == nil {
+		for _, entry := range entries {
+			seen[strings.TrimSuffix(entry.Name(), ".json")] = true
+		}
+	}
+	for _, dir := range themeDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+				continue
+			}
+			seen[strings.TrimSuffix(entry.Name(), ".json")] = true
 		}
 	}
 
-	// Try local themes directory
-	themePath := filepath.Join("themes", name+".json")
-	if theme, err := loadThemeFile(themePath); err == nil {
-		return theme, nil
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
+}
 
-	// Fall back to default theme
-	return defaultTheme(), nil
+// themeDirs returns the directories searched for theme files, in priority
+// order: the user config directory, then ./themes.
+func themeDirs() []string {
+	var dirs []string
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".config", "bubblefetch", "themes"))
+	}
+	return append(dirs, "themes")
 }
 
-func loadThemeFile(path string) (*Theme, error) {
+// bundledTheme returns the theme file bundled in the binary under name, or
+// nil if there is none.
+func bundledTheme(name string) []byte {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil
+	}
+	data, err := themes.FS.ReadFile(name + ".json")
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// resolve loads the named theme; chain holds the themes already being
+// resolved, to detect inheritance cycles.
+func resolve(name string, chain []string) (*Theme, error) {
+	for _, seen := range chain {
+		if seen == name {
+			return nil, fmt.Errorf("theme inheritance cycle: %s -> %s", strings.Join(chain, " -> "), name)
+		}
+	}
+
+	if IsImport(name) {
+		return importTheme(name)
+	}
+
+	path, err := Path(name)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		if data := bundledTheme(name); data != nil {
+			return parseTheme(filepath.Join("themes", name+".json"), data, append(chain, name))
+		}
+		return builtinTheme(), nil
+	}
+
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	return parseTheme(path, data, append(chain, name))
+}
+
+// parseTheme validates a theme file and merges it over the theme it extends.
+func parseTheme(path string, data []byte, chain []string) (*Theme, error) {
+	errs, positions := validate(data)
+	if len(errs) > 0 {
+		joined := make([]error, len(errs))
+		for i, verr := range errs {
+			joined[i] = fmt.Errorf("%s:%w", path, verr)
+		}
+		return nil, errors.Join(joined...)
+	}
+
+	var header struct {
+		Extends string `json:"extends"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
 
 	var theme Theme
-	if err := json.Unmarshal(data, &theme); err != nil {
-		return nil, err
+	if header.Extends != "" {
+		parent, err := resolve(header.Extends, chain)
+		if err != nil {
+			line, col := position(data, positions["extends"])
+			return nil, fmt.Errorf("%s:%d:%d: extends: %w", path, line, col, err)
+		}
+		theme = *parent
+		theme.Name = ""
 	}
 
-	// Auto-detect ASCII art if not set or set to "auto"
-	theme.asciiAuto = theme.ASCII == "" || theme.ASCII == "auto"
-	if theme.asciiAuto {
-		detectedOS := DetectOS()
-		theme.ASCII = GetASCIIArt(detectedOS)
+	// Unmarshal only overwrites the fields present in the file, so unset
+	// fields keep the parent's values.
+	if err := json.Unmarshal(data, &theme); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if theme.Name == "" {
+		theme.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+	// A logo path in a theme file is relative to that file.
+	if _, ok := positions["logo"]; ok && isRelativePath(theme.Logo) {
+		theme.Logo = filepath.Join(filepath.Dir(path), theme.Logo)
 	}
 
 	return &theme, nil
 }
 
-func defaultTheme() *Theme {
-	detectedOS := DetectOS()
+// isRelativePath reports whether a logo is a relative file path rather than
+// "auto", "none", or a path from the root or home directory.
+func isRelativePath(logo string) bool {
+	switch {
+	case logo == "", logo == "auto", logo == "none":
+		return false
+	case logo == "~", strings.HasPrefix(logo, "~/"):
+		return false
+	}
+	return !filepath.IsAbs(logo)
+}
+
+// resolveASCII replaces "auto" (or empty) ASCII art with art for the
+// detected OS.
+func (t *Theme) resolveASCII() {
+	t.asciiAuto = t.ASCII == "" || t.ASCII == "auto"
+	if t.asciiAuto {
+		t.ASCII = GetASCIIArt(DetectOS())
+	}
+}
+
+func builtinTheme() *Theme {
 	return &Theme{
 		Name: "default",
 		Colors: Colors{
@@ -99,8 +307,7 @@ func defaultTheme() *Theme {
 			Border:     "#585b70",
 			Background: "#1e1e2e",
 		},
-		ASCII:     GetASCIIArt(detectedOS),
-		asciiAuto: true,
+		ASCII: "auto",
 		Layout: Layout{
 			ShowASCII:   true,
 			ASCIIWidth:  30,
@@ -119,7 +326,24 @@ func (t *Theme) ApplyAutoASCII(osName string) {
 	t.ASCII = GetASCIIArt(osName)
 }
 
-// GetStyles creates lipgloss styles from the theme
+// PlainStyles returns unstyled Styles, for rendering modules as plain text.
+func PlainStyles() Styles {
+	return Styles{
+		Title:     lipgloss.NewStyle(),
+		Label:     lipgloss.NewStyle(),
+		Value:     lipgloss.NewStyle(),
+		Separator: lipgloss.NewStyle(),
+		ASCII:     lipgloss.NewStyle(),
+		Border:    lipgloss.NewStyle(),
+		Container: lipgloss.NewStyle(),
+		Good:      lipgloss.NewStyle(),
+		Warning:   lipgloss.NewStyle(),
+		Critical:  lipgloss.NewStyle(),
+	}
+}
+
+// GetStyles creates lipgloss styles from the theme, with colors reduced to
+// what the active color profile can show.
 func (t *Theme) GetStyles() Styles {
 	var borderStyle lipgloss.Border
 	switch t.Layout.BorderStyle {
@@ -133,21 +357,56 @@ func (t *Theme) GetStyles() Styles {
 		borderStyle = lipgloss.NormalBorder()
 	}
 
+	profile := lipgloss.ColorProfile()
+	color := func(value string) lipgloss.Color {
+		return downsample(value, profile)
+	}
+	colors := t.Colors.WithDefaults()
+
 	return Styles{
+		Title: lipgloss.NewStyle().
+			Foreground(color(t.Colors.Secondary)).
+			Bold(true),
 		Label: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(t.Colors.Label)).
+			Foreground(color(t.Colors.Label)).
 			Bold(true),
 		Value: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(t.Colors.Value)),
+			Foreground(color(t.Colors.Value)),
 		Separator: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(t.Colors.Primary)),
+			Foreground(color(t.Colors.Primary)),
 		ASCII: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(t.Colors.Primary)),
+			Foreground(color(t.Colors.Primary)),
 		Border: lipgloss.NewStyle().
 			BorderStyle(borderStyle).
-			BorderForeground(lipgloss.Color(t.Colors.Border)).
+			BorderForeground(color(t.Colors.Border)).
 			Padding(0, t.Layout.Padding),
 		Container: lipgloss.NewStyle().
 			Padding(1, 2),
+		ASCIIColors: []lipgloss.Style{
+			lipgloss.NewStyle().Foreground(color(t.Colors.Primary)),
+			lipgloss.NewStyle().Foreground(color(t.Colors.Secondary)),
+			lipgloss.NewStyle().Foreground(color(t.Colors.Accent)),
+			lipgloss.NewStyle().Foreground(color(t.Colors.Label)),
+			lipgloss.NewStyle().Foreground(color(t.Colors.Value)),
+			lipgloss.NewStyle().Foreground(color(t.Colors.Border)),
+		},
+		Good:     lipgloss.NewStyle().Foreground(color(colors.Good)),
+		Warning:  lipgloss.NewStyle().Foreground(color(colors.Warning)),
+		Critical: lipgloss.NewStyle().Foreground(color(colors.Critical)),
+	}
+}
+
+// WithDefaults returns the colors with the unset Good, Warning and Critical
+// colors filled in.
+func (c Colors) WithDefaults() Colors {
+	if c.Good == "" {
+		c.Good = c.Value
+	}
+	if c.Warning == "" {
+		c.Warning = c.Label
+	}
+	if c.Critical == "" {
+		c.Critical = c.Accent
 	}
+	return c
 }
@@ -0,0 +1,296 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError is a problem in a theme file, located by line and column.
+type ValidationError struct {
+	Line    int
+	Column  int
+	Field   string // JSON path, e.g. "colors.primary"; empty for syntax errors
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, e.Field, e.Message)
+}
+
+type fieldKind int
+
+const (
+	kindObject fieldKind = iota
+	kindString
+	kindColor
+	kindBool
+	kindInt
+	kindArray
+)
+
+// fieldSpec describes the expected shape of one theme field.
+type fieldSpec struct {
+	kind   fieldKind
+	fields map[string]fieldSpec
+	enum   []string
+	items  *fieldSpec
+}
+
+// BorderStyles are the accepted layout.border_style values.
+var BorderStyles = []string{"rounded", "double", "thick", "normal", "none"}
+
+var colorsSchema = fieldSpec{kind: kindObject, fields: map[string]fieldSpec{
+	"primary":    {kind: kindColor},
+	"secondary":  {kind: kindColor},
+	"accent":     {kind: kindColor},
+	"label":      {kind: kindColor},
+	"value":      {kind: kindColor},
+	"border":     {kind: kindColor},
+	"background": {kind: kindColor},
+	"good":       {kind: kindColor},
+	"warning":    {kind: kindColor},
+	"critical":   {kind: kindColor},
+}}
+
+var themeSchema = fieldSpec{kind: kindObject, fields: map[string]fieldSpec{
+	"name":    {kind: kindString},
+	"extends": {kind: kindString},
+	"ascii":   {kind: kindString},
+	"logo":    {kind: kindString},
+	"colors":  colorsSchema,
+	"light":   colorsSchema,
+	"dark":    colorsSchema,
+	"layout": {kind: kindObject, fields: map[string]fieldSpec{
+		"show_ascii":     {kind: kindBool},
+		"ascii_width":    {kind: kindInt},
+		"separator":      {kind: kindString},
+		"padding":        {kind: kindInt},
+		"border_style":   {kind: kindString, enum: BorderStyles},
+		"ascii_position": {kind: kindString, enum: ASCIIPositions},
+		"columns":        {kind: kindInt},
+		"header":         {kind: kindArray, items: &fieldSpec{kind: kindString}},
+		"footer":         {kind: kindArray, items: &fieldSpec{kind: kindString}},
+		"sections": {kind: kindArray, items: &fieldSpec{kind: kindObject, fields: map[string]fieldSpec{
+			"title":   {kind: kindString},
+			"modules": {kind: kindArray, items: &fieldSpec{kind: kindString}},
+		}}},
+	}},
+}}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Validate checks theme JSON against the theme schema and returns every
+// problem found, in file order.
+func Validate(data []byte) []*ValidationError {
+	errs, _ := validate(data)
+	return errs
+}
+
+// validate is Validate that also returns the byte offset of each field
+// value, keyed by JSON path.
+func validate(data []byte) ([]*ValidationError, map[string]int) {
+	v := &validator{
+		data:      data,
+		dec:       json.NewDecoder(bytes.NewReader(data)),
+		positions: make(map[string]int),
+	}
+	v.dec.UseNumber()
+
+	if err := v.value("", &themeSchema); err != nil {
+		v.syntaxError(err)
+		return v.errs, v.positions
+	}
+	if offset := v.next(); offset < len(data) {
+		v.fail(offset, "", "unexpected data after the theme object")
+	}
+	return v.errs, v.positions
+}
+
+type validator struct {
+	data      []byte
+	dec       *json.Decoder
+	errs      []*ValidationError
+	positions map[string]int
+}
+
+// value reads one JSON value and checks it against spec; a nil spec accepts
+// anything. The returned error is a syntax error that stops validation.
+func (v *validator) value(path string, spec *fieldSpec) error {
+	offset := v.next()
+	v.positions[path] = offset
+	tok, err := v.dec.Token()
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		return v.skip(tok)
+	}
+
+	switch spec.kind {
+	case kindObject:
+		if tok != json.Delim('{') {
+			if path == "" {
+				v.fail(offset, path, "theme must be a JSON object")
+			} else {
+				v.fail(offset, path, "expected an object")
+			}
+			return v.skip(tok)
+		}
+		for v.dec.More() {
+			keyOffset := v.next()
+			keyTok, err := v.dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := keyTok.(string)
+			field := key
+			if path != "" {
+				field = path + "." + key
+			}
+			child, ok := spec.fields[key]
+			if !ok {
+				v.fail(keyOffset, field, "unknown field")
+				if err := v.value(field, nil); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := v.value(field, &child); err != nil {
+				return err
+			}
+		}
+		_, err := v.dec.Token()
+		return err
+	case kindArray:
+		if tok != json.Delim('[') {
+			v.fail(offset, path, "expected an array")
+			return v.skip(tok)
+		}
+		for i := 0; v.dec.More(); i++ {
+			if err := v.value(fmt.Sprintf("%s[%d]", path, i), spec.items); err != nil {
+				return err
+			}
+		}
+		_, err := v.dec.Token()
+		return err
+	case kindString, kindColor:
+		s, ok := tok.(string)
+		if !ok {
+			v.fail(offset, path, "expected a string")
+			return v.skip(tok)
+		}
+		if spec.kind == kindColor && s != "" && !validColor(s) {
+			v.fail(offset, path, fmt.Sprintf("invalid color %q (use #rgb, #rrggbb, or an ANSI color number 0-255)", s))
+		}
+		if len(spec.enum) > 0 && s != "" && !contains(spec.enum, s) {
+			v.fail(offset, path, fmt.Sprintf("invalid value %q (use %s)", s, strings.Join(spec.enum, ", ")))
+		}
+	case kindBool:
+		if _, ok := tok.(bool); !ok {
+			v.fail(offset, path, "expected true or false")
+			return v.skip(tok)
+		}
+	case kindInt:
+		number, ok := tok.(json.Number)
+		if !ok {
+			v.fail(offset, path, "expected a number")
+			return v.skip(tok)
+		}
+		n, err := strconv.Atoi(number.String())
+		if err != nil {
+			v.fail(offset, path, "expected a whole number")
+		} else if n < 0 {
+			v.fail(offset, path, "must not be negative")
+		}
+	}
+	return nil
+}
+
+// skip consumes the rest of a value whose first token has been read.
+func (v *validator) skip(tok json.Token) error {
+	if tok != json.Delim('{') && tok != json.Delim('[') {
+		return nil
+	}
+	for depth := 1; depth > 0; {
+		tok, err := v.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	return nil
+}
+
+// next returns the offset of the next token, skipping whitespace and the
+// separators the decoder has not consumed yet.
+func (v *validator) next() int {
+	offset := int(v.dec.InputOffset())
+	for offset < len(v.data) && strings.IndexByte(" \t\r\n:,", v.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func (v *validator) fail(offset int, field, message string) {
+	line, col := position(v.data, offset)
+	v.errs = append(v.errs, &ValidationError{Line: line, Column: col, Field: field, Message: message})
+}
+
+func (v *validator) syntaxError(err error) {
+	var syntax *json.SyntaxError
+	switch {
+	case errors.As(err, &syntax):
+		offset := int(syntax.Offset)
+		if offset > 0 {
+			offset--
+		}
+		v.fail(offset, "", strings.TrimPrefix(syntax.Error(), "json: "))
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		v.fail(len(v.data), "", "unexpected end of file")
+	default:
+		v.fail(v.next(), "", err.Error())
+	}
+}
+
+// position converts a byte offset into a 1-based line and column.
+func position(data []byte, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+	return line, utf8.RuneCount(before[lineStart:]) + 1
+}
+
+func validColor(s string) bool {
+	if hexColorPattern.MatchString(s) {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+func contains(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package theme
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Terminal backgrounds that select a theme's light or dark colors.
+const (
+	BackgroundDark  = "dark"
+	BackgroundLight = "light"
+)
+
+var (
+	backgroundOnce   sync.Once
+	backgroundDetect = func() string { return BackgroundDark }
+	background       string
+)
+
+// SetBackground sets how the terminal background is found when a theme
+// defines light or dark colors. detect runs at most once, the first time
+// such a theme is loaded, so themes without variants never pay for it.
+func SetBackground(detect func() string) {
+	backgroundOnce = sync.Once{}
+	backgroundDetect = detect
+}
+
+func currentBackground() string {
+	backgroundOnce.Do(func() {
+		background = backgroundDetect()
+	})
+	return background
+}
+
+// applyVariant overlays the light or dark colors matching the terminal
+// background. Empty variant fields keep the base colors.
+func (t *Theme) applyVariant() {
+	if t.Light == nil && t.Dark == nil {
+		return
+	}
+	variant := t.Dark
+	if currentBackground() == BackgroundLight {
+		variant = t.Light
+	}
+	if variant == nil {
+		return
+	}
+
+	overlay := func(field *string, value string) {
+		if value != "" {
+			*field = value
+		}
+	}
+	overlay(&t.Colors.Primary, variant.Primary)
+	overlay(&t.Colors.Secondary, variant.Secondary)
+	overlay(&t.Colors.Accent, variant.Accent)
+	overlay(&t.Colors.Label, variant.Label)
+	overlay(&t.Colors.Value, variant.Value)
+	overlay(&t.Colors.Border, variant.Border)
+	overlay(&t.Colors.Background, variant.Background)
+	overlay(&t.Colors.Good, variant.Good)
+	overlay(&t.Colors.Warning, variant.Warning)
+	overlay(&t.Colors.Critical, variant.Critical)
+}
+
+// downsample converts a theme color to the closest color the profile can
+// show: an ANSI 256 or ANSI 16 index, or no color at all. Doing this once
+// here keeps every style using the same fallback color.
+func downsample(value string, profile termenv.Profile) lipgloss.Color {
+	if value == "" || profile == termenv.TrueColor {
+		return lipgloss.Color(value)
+	}
+	switch c := profile.Color(value).(type) {
+	case termenv.ANSI256Color:
+		return lipgloss.Color(strconv.Itoa(int(c)))
+	case termenv.ANSIColor:
+		return lipgloss.Color(strconv.Itoa(int(c)))
+	case termenv.RGBColor:
+		return lipgloss.Color(string(c))
+	default:
+		return lipgloss.Color("")
+	}
+}
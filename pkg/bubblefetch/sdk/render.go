@@ -0,0 +1,158 @@
+package sdk
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Styles are the active theme's styles.
+type Styles struct {
+	Title     lipgloss.Style
+	Label     lipgloss.Style
+	Value     lipgloss.Style
+	Separator lipgloss.Style
+	// Primary, Secondary and Accent are text in the theme's colors.
+	Primary   lipgloss.Style
+	Secondary lipgloss.Style
+	Accent    lipgloss.Style
+	// Good, Warning and Critical color usage levels.
+	Good     lipgloss.Style
+	Warning  lipgloss.Style
+	Critical lipgloss.Style
+}
+
+// Field renders a "Label: value" line like the built-in modules. icon may
+// be empty.
+func Field(icon, label, value string, styles Styles) string {
+	if icon != "" {
+		label = styles.Separator.Render(icon) + styles.Label.Render(" "+label)
+	} else {
+		label = styles.Label.Render(label)
+	}
+	return label + styles.Separator.Render(": ") + styles.Value.Render(value)
+}
+
+// Glyph sets for Bar.
+const (
+	BarBlocks  = "blocks"
+	BarBraille = "braille"
+	BarASCII   = "ascii"
+)
+
+// DefaultBarWidth is the width of a bar, in cells, when none is set.
+const DefaultBarWidth = 10
+
+// Thresholds are the percentages at which a bar turns the warning and
+// critical colors. Low makes values at or below them the bad ones, as for a
+// battery's charge.
+type Thresholds struct {
+	Warning  float64
+	Critical float64
+	Low      bool
+}
+
+// Default thresholds for usage and for charge levels.
+var (
+	UsageThresholds  = Thresholds{Warning: 70, Critical: 90}
+	ChargeThresholds = Thresholds{Warning: 30, Critical: 15, Low: true}
+)
+
+// Style returns the Good, Warning or Critical style for percent.
+func (t Thresholds) Style(percent float64, styles Styles) lipgloss.Style {
+	reached := func(level float64) bool {
+		if t.Low {
+			return percent <= level
+		}
+		return percent >= level
+	}
+	switch {
+	case reached(t.Critical):
+		return styles.Critical
+	case reached(t.Warning):
+		return styles.Warning
+	default:
+		return styles.Good
+	}
+}
+
+// BarOptions controls how Bar draws. Zero fields take the defaults:
+// DefaultBarWidth cells of blocks colored by UsageThresholds.
+type BarOptions struct {
+	Width      int
+	Glyphs     string
+	Thresholds Thresholds
+}
+
+// glyphSet draws a bar: full cells, the partial cells between empty and
+// full in increasing order, and empty cells.
+type glyphSet struct {
+	full    string
+	partial []string
+	empty   string
+}
+
+var glyphSets = map[string]glyphSet{
+	BarBlocks:  {full: "█", partial: []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉"}, empty: "░"},
+	BarBraille: {full: "⣿", partial: []string{"⣄", "⣤", "⣦", "⣶", "⣷"}, empty: "⣀"},
+	BarASCII:   {full: "#", empty: "-"},
+}
+
+// Bar draws percent as a usage bar like the memory, disk and battery
+// modules: colored by the thresholds, with the unfilled part faint.
+func Bar(percent float64, opts BarOptions, styles Styles) string {
+	if opts.Thresholds == (Thresholds{}) {
+		opts.Thresholds = UsageThresholds
+	}
+	filled, empty := BarCells(percent, opts)
+	style := opts.Thresholds.Style(percent, styles)
+	var b strings.Builder
+	if filled != "" {
+		b.WriteString(style.Render(filled))
+	}
+	if empty != "" {
+		b.WriteString(style.Faint(true).Render(empty))
+	}
+	return b.String()
+}
+
+// BarCells returns the filled and empty parts of a bar, unstyled.
+func BarCells(percent float64, opts BarOptions) (filled, empty string) {
+	width := opts.Width
+	if width <= 0 {
+		width = DefaultBarWidth
+	}
+	glyphs, ok := glyphSets[opts.Glyphs]
+	if !ok {
+		glyphs = glyphSets[BarBlocks]
+	}
+
+	// Measure in steps of a partial cell.
+	steps := len(glyphs.partial) + 1
+	fill := int(math.Round(min(max(percent, 0), 100) / 100 * float64(width*steps)))
+	full, rest := fill/steps, fill%steps
+
+	filled = strings.Repeat(glyphs.full, full)
+	cells := full
+	if rest > 0 {
+		filled += glyphs.partial[rest-1]
+		cells++
+	}
+	return filled, strings.Repeat(glyphs.empty, width-cells)
+}
+
+// FormatBytes formats a byte count with binary units, like "1.5 GiB".
+func FormatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
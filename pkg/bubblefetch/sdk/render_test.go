@@ -0,0 +1,50 @@
+package sdk
+
+import "testing"
+
+func TestBarCells(t *testing.T) {
+	tests := []struct {
+		name    string
+		percent float64
+		opts    BarOptions
+		filled  string
+		empty   string
+	}{
+		{name: "empty", percent: 0, filled: "", empty: "░░░░░░░░░░"},
+		{name: "half", percent: 50, filled: "█████", empty: "░░░░░"},
+		{name: "partial cell", percent: 55, filled: "█████▌", empty: "░░░░"},
+		{name: "full", percent: 100, filled: "██████████", empty: ""},
+		{name: "over 100", percent: 130, opts: BarOptions{Width: 4}, filled: "████", empty: ""},
+		{name: "below 0", percent: -5, opts: BarOptions{Width: 4}, filled: "", empty: "░░░░"},
+		{name: "ascii", percent: 25, opts: BarOptions{Width: 8, Glyphs: BarASCII}, filled: "##", empty: "------"},
+		{name: "braille", percent: 50, opts: BarOptions{Width: 3, Glyphs: BarBraille}, filled: "⣿⣦", empty: "⣀"},
+		{name: "unknown glyphs", percent: 50, opts: BarOptions{Width: 2, Glyphs: "stars"}, filled: "█", empty: "░"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filled, empty := BarCells(tt.percent, tt.opts)
+			if filled != tt.filled || empty != tt.empty {
+				t.Errorf("BarCells(%g) = %q, %q, want %q, %q", tt.percent, filled, empty, tt.filled, tt.empty)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		bytes uint64
+		want  string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{8589934592, "8.0 GiB"},
+		{1 << 60, "1.0 EiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.bytes); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
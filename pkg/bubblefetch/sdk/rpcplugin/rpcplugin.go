@@ -0,0 +1,203 @@
+// Package rpcplugin helps write bubblefetch RPC plugins in Go. An RPC plugin
+// is an executable in the plugins/rpc directory that bubblefetch starts once
+// per run and talks to over JSON-RPC 2.0 on stdin and stdout, one message
+// per line. Unlike Go .so plugins it needs no matching toolchain, and unlike
+// external modules it is not restarted for every render.
+//
+// A plugin's main function calls Serve:
+//
+//	func main() {
+//		rpcplugin.Serve(rpcplugin.Plugin{
+//			Render: func(req rpcplugin.Request) (rpcplugin.Output, error) {
+//				return rpcplugin.Output{Label: "Host", Value: req.System.Hostname}, nil
+//			},
+//		})
+//	}
+package rpcplugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
+)
+
+// ProtocolVersion is the version of the RPC protocol. bubblefetch refuses
+// plugins that answer the handshake with another version.
+const ProtocolVersion = 1
+
+// Methods bubblefetch calls.
+const (
+	MethodInitialize = "initialize" // Handshake, once before anything else
+	MethodPing       = "ping"       // Health check
+	MethodRender     = "render"     // Output for a module
+	MethodShutdown   = "shutdown"   // Notification sent before stdin is closed
+)
+
+// Host is what bubblefetch sends in the initialize handshake.
+type Host struct {
+	ProtocolVersion int    `json:"protocol_version"`
+	Version         string `json:"version"` // bubblefetch version
+	Theme           Colors `json:"theme"`
+	// Config is the plugin's entry under plugin_config in the bubblefetch
+	// config.
+	Config map[string]any `json:"config"`
+	// Remote is the remote target being fetched, like "user@host"; empty
+	// for the local system.
+	Remote string `json:"remote"`
+	// TerminalWidth is the width bubblefetch renders for, in columns, or 0
+	// when output is not a terminal.
+	TerminalWidth int `json:"terminal_width"`
+}
+
+// Colors are the active theme's colors, as hex strings.
+type Colors struct {
+	Primary    string `json:"primary"`
+	Secondary  string `json:"secondary"`
+	Accent     string `json:"accent"`
+	Label      string `json:"label"`
+	Value      string `json:"value"`
+	Border     string `json:"border"`
+	Background string `json:"background"`
+	Good       string `json:"good"`
+	Warning    string `json:"warning"`
+	Critical   string `json:"critical"`
+}
+
+// InitializeResult is the plugin's answer to the handshake.
+type InitializeResult struct {
+	ProtocolVersion int `json:"protocol_version"`
+}
+
+// RenderParams are the parameters of a render call.
+type RenderParams struct {
+	Module string         `json:"module"`
+	System sdk.SystemInfo `json:"system"`
+}
+
+// Request is a render call with the handshake's host information.
+type Request struct {
+	Module string
+	System sdk.SystemInfo
+	Host   Host
+}
+
+// Output is a module's output. bubblefetch styles it like the built-in
+// modules: "Label: Value", followed by Lines indented beneath it. An empty
+// Output hides the module.
+type Output struct {
+	Label     string   `json:"label,omitempty"`
+	Value     string   `json:"value,omitempty"`
+	Icon      string   `json:"icon,omitempty"`
+	Separator string   `json:"separator,omitempty"` // Default ": "
+	Lines     []string `json:"lines,omitempty"`
+}
+
+// Plugin is the implementation of an RPC plugin.
+type Plugin struct {
+	// Init is called with the handshake, before any Render. It may be nil.
+	Init func(Host) error
+	// Render returns the output of the module named in the request. An
+	// error is reported by bubblefetch as a warning and hides the module.
+	Render func(Request) (Output, error)
+}
+
+// Serve answers bubblefetch on stdin and stdout until stdin is closed or
+// shutdown is received. Plugins must not write anything else to stdout;
+// stderr is discarded.
+func Serve(p Plugin) error {
+	return serve(p, os.Stdin, os.Stdout)
+}
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+type request struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *responseError  `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func serve(p Plugin, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	enc := json.NewEncoder(w)
+
+	var host Host
+	for scanner.Scan() {
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			if err := enc.Encode(response{Version: "2.0", ID: json.RawMessage("null"), Error: &responseError{codeParseError, err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, rerr := handle(p, &host, req)
+		// Notifications, which have no ID, get no response.
+		if len(req.ID) > 0 {
+			if err := enc.Encode(response{Version: "2.0", ID: req.ID, Result: result, Error: rerr}); err != nil {
+				return err
+			}
+		}
+		if req.Method == MethodShutdown {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+func handle(p Plugin, host *Host, req request) (any, *responseError) {
+	switch req.Method {
+	case MethodInitialize:
+		if err := json.Unmarshal(req.Params, host); err != nil {
+			return nil, &responseError{codeInvalidParams, err.Error()}
+		}
+		if p.Init != nil {
+			if err := p.Init(*host); err != nil {
+				return nil, &responseError{codeInternalError, err.Error()}
+			}
+		}
+		return InitializeResult{ProtocolVersion: ProtocolVersion}, nil
+	case MethodPing:
+		return struct{}{}, nil
+	case MethodRender:
+		var params RenderParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &responseError{codeInvalidParams, err.Error()}
+		}
+		if p.Render == nil {
+			return Output{}, nil
+		}
+		output, err := p.Render(Request{Module: params.Module, System: params.System, Host: *host})
+		if err != nil {
+			return nil, &responseError{codeInternalError, err.Error()}
+		}
+		return output, nil
+	case MethodShutdown:
+		return struct{}{}, nil
+	default:
+		return nil, &responseError{codeMethodNotFound, fmt.Sprintf("unknown method %q", req.Method)}
+	}
+}
@@ -0,0 +1,200 @@
+package rpcplugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// testConn drives serve over pipes, as bubblefetch does over stdio.
+type testConn struct {
+	t     *testing.T
+	in    *io.PipeWriter
+	out   *bufio.Scanner
+	lines chan string
+	done  chan error
+}
+
+func startServe(t *testing.T, p Plugin) *testConn {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	c := &testConn{
+		t:     t,
+		in:    inW,
+		out:   bufio.NewScanner(outR),
+		lines: make(chan string),
+		done:  make(chan error, 1),
+	}
+	go func() {
+		err := serve(p, inR, outW)
+		outW.Close()
+		c.done <- err
+	}()
+	go func() {
+		defer close(c.lines)
+		for c.out.Scan() {
+			c.lines <- c.out.Text()
+		}
+	}()
+	t.Cleanup(func() { inW.Close() })
+	return c
+}
+
+func (c *testConn) send(line string) {
+	c.t.Helper()
+	if _, err := io.WriteString(c.in, line+"\n"); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// recv returns the next response, failing the test if none arrives in time.
+func (c *testConn) recv() map[string]any {
+	c.t.Helper()
+	select {
+	case line, ok := <-c.lines:
+		if !ok {
+			c.t.Fatal("serve closed its output")
+		}
+		var resp map[string]any
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			c.t.Fatalf("invalid response %q: %v", line, err)
+		}
+		return resp
+	case <-time.After(time.Second):
+		c.t.Fatal("timeout waiting for a response")
+		return nil
+	}
+}
+
+// wait returns serve's result, failing the test if it does not return in time.
+func (c *testConn) wait() error {
+	c.t.Helper()
+	select {
+	case err := <-c.done:
+		return err
+	case <-time.After(time.Second):
+		c.t.Fatal("serve did not return")
+		return nil
+	}
+}
+
+func errorCode(resp map[string]any) float64 {
+	e, _ := resp["error"].(map[string]any)
+	code, _ := e["code"].(float64)
+	return code
+}
+
+func TestServeHandshake(t *testing.T) {
+	var got Host
+	c := startServe(t, Plugin{
+		Init: func(h Host) error {
+			got = h
+			return nil
+		},
+		Render: func(req Request) (Output, error) {
+			return Output{Label: "City", Value: req.Host.Config["city"].(string) + " on " + req.System.Hostname}, nil
+		},
+	})
+
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocol_version":1,"version":"1.2.3","config":{"city":"Berlin"},"terminal_width":80}}`)
+	resp := c.recv()
+	if resp["id"] != 1.0 || resp["result"].(map[string]any)["protocol_version"] != float64(ProtocolVersion) {
+		t.Fatalf("initialize response = %v", resp)
+	}
+	if got.Version != "1.2.3" || got.TerminalWidth != 80 {
+		t.Errorf("Init got %+v", got)
+	}
+
+	c.send(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	if resp := c.recv(); resp["id"] != 2.0 || resp["error"] != nil {
+		t.Errorf("ping response = %v", resp)
+	}
+
+	c.send(`{"jsonrpc":"2.0","id":3,"method":"render","params":{"module":"city","system":{"hostname":"box"}}}`)
+	resp = c.recv()
+	if result, _ := resp["result"].(map[string]any); result["value"] != "Berlin on box" {
+		t.Errorf("render response = %v", resp)
+	}
+
+	c.send(`{"jsonrpc":"2.0","method":"shutdown"}`)
+	if err := c.wait(); err != nil {
+		t.Errorf("serve() = %v after shutdown", err)
+	}
+}
+
+// A host with another protocol version still gets the plugin's version, so
+// that it can refuse the plugin with a clear message.
+func TestServeVersionMismatch(t *testing.T) {
+	c := startServe(t, Plugin{})
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocol_version":99}}`)
+	resp := c.recv()
+	if resp["result"].(map[string]any)["protocol_version"] != float64(ProtocolVersion) {
+		t.Errorf("initialize response = %v, want protocol_version %d", resp, ProtocolVersion)
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	c := startServe(t, Plugin{
+		Init: func(Host) error { return errors.New("no API key") },
+		Render: func(Request) (Output, error) {
+			return Output{}, errors.New("offline")
+		},
+	})
+	tests := []struct {
+		name    string
+		request string
+		code    float64
+	}{
+		{"parse error", `{"jsonrpc":`, codeParseError},
+		{"unknown method", `{"jsonrpc":"2.0","id":1,"method":"nope"}`, codeMethodNotFound},
+		{"invalid params", `{"jsonrpc":"2.0","id":2,"method":"render","params":[]}`, codeInvalidParams},
+		{"init error", `{"jsonrpc":"2.0","id":3,"method":"initialize","params":{}}`, codeInternalError},
+		{"render error", `{"jsonrpc":"2.0","id":4,"method":"render","params":{}}`, codeInternalError},
+	}
+	for _, tt := range tests {
+		c.send(tt.request)
+		if resp := c.recv(); errorCode(resp) != tt.code {
+			t.Errorf("%s: response = %v, want error code %v", tt.name, resp, tt.code)
+		}
+	}
+}
+
+// A render that outlives the host's timeout is answered late under its own
+// ID, so the host can discard it and match the next response.
+func TestServeSlowRender(t *testing.T) {
+	release := make(chan struct{})
+	c := startServe(t, Plugin{
+		Render: func(Request) (Output, error) {
+			<-release
+			return Output{Value: "late"}, nil
+		},
+	})
+
+	// One write, since a pipe write blocks until serve reads it.
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"render","params":{}}` + "\n" + `{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	select {
+	case line := <-c.lines:
+		t.Fatalf("response %s before the render finished", line)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if resp := c.recv(); resp["id"] != 1.0 {
+		t.Errorf("first response = %v, want the render", resp)
+	}
+	if resp := c.recv(); resp["id"] != 2.0 {
+		t.Errorf("second response = %v, want the ping", resp)
+	}
+}
+
+func TestServeStdinClosed(t *testing.T) {
+	c := startServe(t, Plugin{})
+	c.in.Close()
+	if err := c.wait(); err != nil {
+		t.Errorf("serve() = %v when stdin is closed", err)
+	}
+}
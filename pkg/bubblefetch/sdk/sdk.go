@@ -0,0 +1,71 @@
+// Package sdk is the public API for bubblefetch Go plugins. Plugins import
+// it instead of bubblefetch's internal packages, which can change at any
+// time.
+//
+// A plugin is built with -buildmode=plugin and exports three symbols:
+//
+//	var APIVersion = sdk.APIVersion
+//	var ModuleName = "hello"
+//	func Render(info *sdk.SystemInfo, styles sdk.Styles) string
+//
+// bubblefetch checks APIVersion before it calls Render, and refuses plugins
+// built for another version of this API with an error naming both versions.
+package sdk
+
+// APIVersion is the version of the plugin API in this package. It changes
+// when the types or the Render signature change incompatibly.
+const APIVersion = 1
+
+// SystemInfo is the system information collected by bubblefetch. Its JSON
+// form, sent to RPC plugins, uses the field names of the JSON export.
+type SystemInfo struct {
+	OS         string             `json:"os"`
+	Kernel     string             `json:"kernel"`
+	Hostname   string             `json:"hostname"`
+	Uptime     string             `json:"uptime"`
+	CPU        string             `json:"cpu"`
+	Memory     Usage              `json:"memory"`
+	Disk       Usage              `json:"disk"`
+	Shell      string             `json:"shell"`
+	Terminal   string             `json:"terminal"`
+	Resolution string             `json:"resolution"`
+	DE         string             `json:"de"`
+	WM         string             `json:"wm"`
+	Theme      string             `json:"theme"`
+	Icons      string             `json:"icons"`
+	GPU        []string           `json:"gpu"`
+	Network    []NetworkInterface `json:"network"`
+	Battery    Battery            `json:"battery"`
+	LocalIP    string             `json:"local_ip"`
+	PublicIP   string             `json:"public_ip"`
+}
+
+// Usage is used and total space in bytes.
+type Usage struct {
+	Used  uint64 `json:"used_bytes"`
+	Total uint64 `json:"total_bytes"`
+}
+
+// Percent returns the used share of the total, from 0 to 100.
+func (u Usage) Percent() float64 {
+	if u.Total == 0 {
+		return 0
+	}
+	return float64(u.Used) / float64(u.Total) * 100
+}
+
+// NetworkInterface is an active network interface.
+type NetworkInterface struct {
+	Interface string `json:"name"`
+	IPv4      string `json:"ipv4"`
+	IPv6      string `json:"ipv6"`
+	MAC       string `json:"mac"`
+}
+
+// Battery is the state of the battery, if Present.
+type Battery struct {
+	Present    bool    `json:"present"`
+	Percentage float64 `json:"percent"`
+	IsCharging bool    `json:"charging"`
+	TimeRemain string  `json:"time_remaining"`
+}
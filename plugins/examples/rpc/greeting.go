@@ -0,0 +1,52 @@
+// Greeting is an example RPC plugin. bubblefetch starts it once per run and
+// asks it to render the "greeting" module over JSON-RPC on stdio.
+//
+// Build and install:
+//
+//	go build -o ~/.config/bubblefetch/plugins/rpc/greeting ./plugins/examples/rpc
+//
+// Configure the name to greet:
+//
+//	plugin_config:
+//	  greeting:
+//	    name: Ada
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk"
+	"github.com/howieduhzit/bubblefetch/pkg/bubblefetch/sdk/rpcplugin"
+)
+
+func main() {
+	name := "there"
+	err := rpcplugin.Serve(rpcplugin.Plugin{
+		Init: func(host rpcplugin.Host) error {
+			if configured, ok := host.Config["name"].(string); ok && configured != "" {
+				name = configured
+			}
+			return nil
+		},
+		Render: func(req rpcplugin.Request) (rpcplugin.Output, error) {
+			output := rpcplugin.Output{
+				Label: "Greeting",
+				Value: fmt.Sprintf("Hello, %s!", name),
+				Icon:  "👋",
+			}
+			if req.System.Hostname != "" {
+				output.Lines = append(output.Lines, "Welcome to "+req.System.Hostname)
+			}
+			if req.System.Memory.Total > 0 {
+				output.Lines = append(output.Lines, fmt.Sprintf("%s of memory free",
+					sdk.FormatBytes(req.System.Memory.Total-req.System.Memory.Used)))
+			}
+			return output, nil
+		},
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,10 @@
+// Package themes bundles the stock theme files into the binary, so they are
+// available without installing them to the themes directory.
+package themes
+
+import "embed"
+
+// FS holds the stock themes, one <name>.json file each.
+//
+//go:embed *.json
+var FS embed.FS